@@ -3,9 +3,12 @@ package langfuse
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
+var ErrOrganizationApiKeyNotFound = fmt.Errorf("organization API key %w", ErrNotFound)
+
 type Organization struct {
 	ID       string            `json:"id"`
 	Name     string            `json:"name"`
@@ -20,6 +23,7 @@ type OrganizationApiKey struct {
 
 type ListOrganizationsResponse struct {
 	Organizations []*Organization `json:"organizations"`
+	Meta          PaginationMeta  `json:"meta"`
 }
 
 type CreateOrganizationRequest struct {
@@ -38,6 +42,7 @@ type deleteOrganizationResponse struct {
 
 type listOrganizationApiKeysResponse struct {
 	ApiKeys []OrganizationApiKey `json:"apiKeys"`
+	Meta    PaginationMeta       `json:"meta"`
 }
 
 type deleteOrganizationApiKeyResponse struct {
@@ -46,53 +51,51 @@ type deleteOrganizationApiKeyResponse struct {
 
 //go:generate mockgen -destination=./mocks/mock_admin_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse AdminClient
 
+// The List methods iterate over every page of a list endpoint, while their Page variants fetch a single one.
 type AdminClient interface {
-	ListOrganizations(ctx context.Context) ([]*Organization, error)
+	ListOrganizations(ctx context.Context) iter.Seq2[*Organization, error]
+	ListOrganizationsPage(ctx context.Context, page, limit int) (*Page[*Organization], error)
 	GetOrganization(ctx context.Context, orgID string) (*Organization, error)
 	CreateOrganization(ctx context.Context, request *CreateOrganizationRequest) (*Organization, error)
 	UpdateOrganization(ctx context.Context, orgID string, request *UpdateOrganizationRequest) (*Organization, error)
 	DeleteOrganization(ctx context.Context, orgID string) error
+	ListOrganizationApiKeys(ctx context.Context, orgID string) iter.Seq2[OrganizationApiKey, error]
+	ListOrganizationApiKeysPage(ctx context.Context, orgID string, page, limit int) (*Page[OrganizationApiKey], error)
 	GetOrganizationApiKey(ctx context.Context, orgID string, apiKeyID string) (*OrganizationApiKey, error)
 	CreateOrganizationApiKey(ctx context.Context, orgID string) (*OrganizationApiKey, error)
 	DeleteOrganizationApiKey(ctx context.Context, orgID string, apiKeyID string) error
 }
 
 type adminClientImpl struct {
-	host       string
-	apiKey     string
-	httpClient *http.Client
+	*apiClient
 }
 
 func NewAdminClient(host, apiKey string) AdminClient {
-	return &adminClientImpl{
-		host:       host,
-		apiKey:     apiKey,
-		httpClient: &http.Client{},
-	}
+	return newAdminClient(newAPIClient(host, bearerAuth{token: apiKey}, newDefaultHTTPClient()))
 }
 
-func (c *adminClientImpl) ListOrganizations(ctx context.Context) ([]*Organization, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, "api/admin/organizations", nil)
-	if err != nil {
-		return nil, err
-	}
+func newAdminClient(client *apiClient) AdminClient {
+	return &adminClientImpl{apiClient: client}
+}
+
+func (c *adminClientImpl) ListOrganizations(ctx context.Context) iter.Seq2[*Organization, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[*Organization], error) {
+		return c.ListOrganizationsPage(ctx, page, DefaultPageSize)
+	})
+}
 
+func (c *adminClientImpl) ListOrganizationsPage(ctx context.Context, page, limit int) (*Page[*Organization], error) {
 	var listOrgResp ListOrganizationsResponse
-	if err := decodeResponse(resp, &listOrgResp); err != nil {
+	if err := c.getJSON(ctx, collectionOrganizations, pagePath("api/admin/organizations", page, limit), &listOrgResp); err != nil {
 		return nil, err
 	}
 
-	return listOrgResp.Organizations, nil
+	return &Page[*Organization]{Items: listOrgResp.Organizations, Meta: listOrgResp.Meta}, nil
 }
 
 func (c *adminClientImpl) GetOrganization(ctx context.Context, orgID string) (*Organization, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("api/admin/organizations/%s", orgID), nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var org Organization
-	if err := decodeResponse(resp, &org); err != nil {
+	if err := c.getJSON(ctx, collectionOrganizations, fmt.Sprintf("api/admin/organizations/%s", orgID), &org); err != nil {
 		return nil, err
 	}
 
@@ -100,6 +103,8 @@ func (c *adminClientImpl) GetOrganization(ctx context.Context, orgID string) (*O
 }
 
 func (c *adminClientImpl) CreateOrganization(ctx context.Context, request *CreateOrganizationRequest) (*Organization, error) {
+	defer c.invalidate(collectionOrganizations)
+
 	resp, err := c.makeRequest(ctx, http.MethodPost, "api/admin/organizations", request)
 	if err != nil {
 		return nil, err
@@ -114,6 +119,8 @@ func (c *adminClientImpl) CreateOrganization(ctx context.Context, request *Creat
 }
 
 func (c *adminClientImpl) UpdateOrganization(ctx context.Context, orgID string, request *UpdateOrganizationRequest) (*Organization, error) {
+	defer c.invalidate(collectionOrganizations)
+
 	resp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("api/admin/organizations/%s", orgID), request)
 	if err != nil {
 		return nil, err
@@ -128,6 +135,8 @@ func (c *adminClientImpl) UpdateOrganization(ctx context.Context, orgID string,
 }
 
 func (c *adminClientImpl) DeleteOrganization(ctx context.Context, orgID string) error {
+	defer c.invalidate(collectionOrganizations, organizationApiKeysCollection(orgID))
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/admin/organizations/%s", orgID), nil)
 	if err != nil {
 		return err
@@ -144,26 +153,38 @@ func (c *adminClientImpl) DeleteOrganization(ctx context.Context, orgID string)
 	return nil
 }
 
-func (c *adminClientImpl) GetOrganizationApiKey(ctx context.Context, orgID string, apiKeyID string) (*OrganizationApiKey, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("api/admin/organizations/%s/apiKeys", orgID), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *adminClientImpl) ListOrganizationApiKeys(ctx context.Context, orgID string) iter.Seq2[OrganizationApiKey, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[OrganizationApiKey], error) {
+		return c.ListOrganizationApiKeysPage(ctx, orgID, page, DefaultPageSize)
+	})
+}
 
+func (c *adminClientImpl) ListOrganizationApiKeysPage(ctx context.Context, orgID string, page, limit int) (*Page[OrganizationApiKey], error) {
+	apiPath := pagePath(fmt.Sprintf("api/admin/organizations/%s/apiKeys", orgID), page, limit)
 	var listOrgApiKeysResp listOrganizationApiKeysResponse
-	if err := decodeResponse(resp, &listOrgApiKeysResp); err != nil {
+	if err := c.getJSON(ctx, organizationApiKeysCollection(orgID), apiPath, &listOrgApiKeysResp); err != nil {
 		return nil, err
 	}
-	for _, key := range listOrgApiKeysResp.ApiKeys {
+
+	return &Page[OrganizationApiKey]{Items: listOrgApiKeysResp.ApiKeys, Meta: listOrgApiKeysResp.Meta}, nil
+}
+
+func (c *adminClientImpl) GetOrganizationApiKey(ctx context.Context, orgID string, apiKeyID string) (*OrganizationApiKey, error) {
+	for key, err := range c.ListOrganizationApiKeys(ctx, orgID) {
+		if err != nil {
+			return nil, err
+		}
 		if key.ID == apiKeyID {
 			return &key, nil
 		}
 	}
 
-	return nil, fmt.Errorf("cannot find API key with ID %s in organization %s", apiKeyID, orgID)
+	return nil, fmt.Errorf("%w: ID %s in organization %s", ErrOrganizationApiKeyNotFound, apiKeyID, orgID)
 }
 
 func (c *adminClientImpl) CreateOrganizationApiKey(ctx context.Context, orgID string) (*OrganizationApiKey, error) {
+	defer c.invalidate(organizationApiKeysCollection(orgID))
+
 	resp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("api/admin/organizations/%s/apiKeys", orgID), nil)
 	if err != nil {
 		return nil, err
@@ -178,6 +199,8 @@ func (c *adminClientImpl) CreateOrganizationApiKey(ctx context.Context, orgID st
 }
 
 func (c *adminClientImpl) DeleteOrganizationApiKey(ctx context.Context, orgID string, apiKeyID string) error {
+	defer c.invalidate(organizationApiKeysCollection(orgID))
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/admin/organizations/%s/apiKeys/%s", orgID, apiKeyID), nil)
 	if err != nil {
 		return err
@@ -193,18 +216,3 @@ func (c *adminClientImpl) DeleteOrganizationApiKey(ctx context.Context, orgID st
 
 	return nil
 }
-
-func (c *adminClientImpl) makeRequest(ctx context.Context, method, apiPath string, body any) (*http.Response, error) {
-	req, err := buildBaseRequest(ctx, method, buildURL(c.host, apiPath), body)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	return resp, nil
-}
@@ -0,0 +1,139 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// authStrategy attaches credentials to an outgoing request, failing it when no credentials are available.
+type authStrategy interface {
+	authenticate(req *http.Request) error
+}
+
+// bearerAuth authenticates with the admin API key.
+type bearerAuth struct {
+	token string
+}
+
+func (a bearerAuth) authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// basicAuth authenticates with an organization or project key pair.
+type basicAuth struct {
+	publicKey string
+	secretKey string
+}
+
+func (a basicAuth) authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.publicKey, a.secretKey)
+	return nil
+}
+
+// unresolvedCredentials fails every request of a client whose credentials could not be resolved, so that
+// the cause is reported instead of the server rejecting an unauthenticated request.
+type unresolvedCredentials struct {
+	err error
+}
+
+func (a unresolvedCredentials) authenticate(*http.Request) error {
+	return a.err
+}
+
+// DefaultUserAgent identifies requests sent by clients that were not given a more specific User-Agent.
+const DefaultUserAgent = "terraform-provider-langfuse"
+
+// apiClient holds what the admin, organization and LLM connections clients have in common: the host,
+// the credentials and the HTTP client whose transport chain is shared by every client of a ClientFactory.
+type apiClient struct {
+	host       string
+	auth       authStrategy
+	httpClient *http.Client
+	userAgent  string
+	// limiter paces the requests sent with these credentials. It is nil when no limit is configured.
+	limiter *requestLimiter
+	// cache is shared by every client of a ClientFactory. It is nil for standalone clients.
+	cache          *responseCache
+	credentialsKey string
+	// readOnly refuses every request that could change the server, before it is sent.
+	readOnly bool
+}
+
+func newAPIClient(host string, auth authStrategy, httpClient *http.Client) *apiClient {
+	return &apiClient{
+		host:       host,
+		auth:       auth,
+		httpClient: httpClient,
+		userAgent:  DefaultUserAgent,
+	}
+}
+
+// makeRequest sends an authenticated JSON request. apiPath is relative to the host and may carry a query string.
+func (c *apiClient) makeRequest(ctx context.Context, method, apiPath string, body any) (*http.Response, error) {
+	if c.readOnly && !isSafeMethod(method) {
+		return nil, fmt.Errorf("refusing to send %s %s: %w", method, apiPath, ErrReadOnly)
+	}
+	if c.limiter != nil {
+		ctx = withRequestLimiter(ctx, c.limiter)
+	}
+
+	req, err := buildBaseRequest(ctx, method, buildURL(c.host, apiPath), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if err := c.auth.authenticate(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// isSafeMethod reports whether requests with method only read from the server.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// getJSON sends an authenticated GET request and decodes the response into target. When the client has a
+// cache, the response is reused for identical requests until a write invalidates its collection.
+func (c *apiClient) getJSON(ctx context.Context, collection, apiPath string, target any) error {
+	load := func(ctx context.Context) ([]byte, error) {
+		resp, err := c.makeRequest(ctx, http.MethodGet, apiPath, nil)
+		if err != nil {
+			return nil, err
+		}
+		return readResponse(resp)
+	}
+
+	var body []byte
+	var err error
+	if c.cache != nil {
+		body, err = c.cache.fetch(ctx, collection, c.credentialsKey+"\x00"+apiPath, load)
+	} else {
+		body, err = load(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	return unmarshalBody(body, target)
+}
+
+// invalidate drops the cached responses of the collections changed by a write. It is called whether the
+// write succeeded or not, since a failed request may still have been applied by the server.
+func (c *apiClient) invalidate(collections ...string) {
+	if c.cache != nil {
+		c.cache.invalidate(collections...)
+	}
+}
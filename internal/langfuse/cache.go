@@ -0,0 +1,110 @@
+package langfuse
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheLoadTimeout bounds a load shared by concurrent callers. The load does not follow the context of the
+// caller that started it, whose deadline or cancellation would otherwise fail every other caller, so it
+// gets the default read timeout of the resources instead.
+const cacheLoadTimeout = 5 * time.Minute
+
+// Collections group the cached list endpoints with the writes that change them. Writes invalidate every
+// cached response of their collection, whichever credentials were used to fetch it.
+const (
+	collectionOrganizations  = "organizations"
+	collectionProjects       = "projects"
+	collectionMemberships    = "memberships"
+	collectionLlmConnections = "llm-connections"
+)
+
+func organizationApiKeysCollection(orgID string) string {
+	return "organizations/" + orgID + "/apiKeys"
+}
+
+func projectApiKeysCollection(projectID string) string {
+	return "projects/" + projectID + "/apiKeys"
+}
+
+func projectMembershipsCollection(projectID string) string {
+	return "projects/" + projectID + "/memberships"
+}
+
+// responseCache keeps the bodies of successful GET responses for the lifetime of a ClientFactory, i.e. a
+// single Terraform run, and makes concurrent identical requests share one round trip. Refreshing many
+// resources that are looked up by scanning the same list therefore only fetches that list once.
+type responseCache struct {
+	mu sync.Mutex
+	// entries holds response bodies by collection, then by credentials and path.
+	entries map[string]map[string][]byte
+	// generations counts the invalidations of each collection, so that a response fetched while a write
+	// was in flight is never stored.
+	generations map[string]uint64
+	group       singleflight.Group
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries:     make(map[string]map[string][]byte),
+		generations: make(map[string]uint64),
+	}
+}
+
+// fetch returns the cached body for key, calling load at most once for concurrent callers otherwise. Each
+// caller stops waiting for the shared load when its own ctx is done.
+func (rc *responseCache) fetch(ctx context.Context, collection, key string, load func(context.Context) ([]byte, error)) ([]byte, error) {
+	rc.mu.Lock()
+	if body, ok := rc.entries[collection][key]; ok {
+		rc.mu.Unlock()
+		return body, nil
+	}
+	generation := rc.generations[collection]
+	rc.mu.Unlock()
+
+	flightKey := collection + "\x00" + strconv.FormatUint(generation, 10) + "\x00" + key
+	results := rc.group.DoChan(flightKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+
+		body, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		if rc.generations[collection] == generation {
+			if rc.entries[collection] == nil {
+				rc.entries[collection] = make(map[string][]byte)
+			}
+			rc.entries[collection][key] = body
+		}
+		return body, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// invalidate drops every cached response of the given collections.
+func (rc *responseCache) invalidate(collections ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, collection := range collections {
+		rc.generations[collection]++
+		delete(rc.entries, collection)
+	}
+}
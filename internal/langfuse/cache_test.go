@@ -0,0 +1,164 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheCoalescesConcurrentLookups(t *testing.T) {
+	t.Parallel()
+
+	var listCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listCalls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"memberships":[{"userId":"user-1","role":"ADMIN"},{"userId":"user-2","role":"MEMBER"}]}`))
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "")
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			userID := fmt.Sprintf("user-%d", i%2+1)
+			membership, err := factory.NewOrganizationClient("", "pk-org", "sk-org").GetProjectMembership(context.Background(), "proj-1", userID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if membership.UserID != userID {
+				t.Errorf("unexpected membership: got %q, want %q", membership.UserID, userID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := listCalls.Load(); got != 1 {
+		t.Fatalf("expected 1 list request, got %d", got)
+	}
+}
+
+func TestCacheInvalidatedByWrites(t *testing.T) {
+	t.Parallel()
+
+	var listCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/public/organizations/projects":
+			listCalls.Add(1)
+			_, _ = w.Write([]byte(`{"projects":[{"id":"proj-1","name":"Project"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/public/projects":
+			_, _ = w.Write([]byte(`{"id":"proj-2","name":"Other"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	factory := NewClientFactory(server.URL, "")
+	client := factory.NewOrganizationClient("", "pk-org", "sk-org")
+
+	for range 3 {
+		if _, err := client.GetProject(ctx, "proj-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := listCalls.Load(); got != 1 {
+		t.Fatalf("expected repeated lookups to share 1 list request, got %d", got)
+	}
+
+	// Other credentials may see other projects, so they never share cached responses.
+	if _, err := Collect(factory.NewOrganizationClient("", "pk-other", "sk-other").ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := listCalls.Load(); got != 2 {
+		t.Fatalf("expected other credentials to send their own request, got %d list requests", got)
+	}
+
+	if _, err := client.CreateProject(ctx, &CreateProjectRequest{Name: "Other"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := listCalls.Load(); got != 3 {
+		t.Fatalf("expected a write to invalidate the cached projects, got %d list requests", got)
+	}
+}
+
+func TestCacheDoesNotKeepErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"memberships":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClientFactory(server.URL, "", WithRetryConfig(RetryConfig{})).NewOrganizationClient("", "pk-org", "sk-org")
+
+	if _, err := Collect(client.ListMemberships(context.Background())); !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	if _, err := Collect(client.ListMemberships(context.Background())); err != nil {
+		t.Fatalf("expected the failed response not to be cached, got %v", err)
+	}
+}
+
+func TestCacheLoadOutlivesCanceledCaller(t *testing.T) {
+	t.Parallel()
+
+	cache := newResponseCache()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var loads atomic.Int32
+	load := func(ctx context.Context) ([]byte, error) {
+		if loads.Add(1) == 1 {
+			close(started)
+		}
+		select {
+		case <-release:
+			return []byte("body"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.fetch(ctx, collectionMemberships, "key", load)
+		firstErr <- err
+	}()
+	<-started
+
+	// The caller that started the load gives up, without failing the load shared with the others.
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the canceled caller to stop waiting, got %v", err)
+	}
+	close(release)
+
+	body, err := cache.fetch(context.Background(), collectionMemberships, "key", load)
+	if err != nil || string(body) != "body" {
+		t.Fatalf("expected the shared load to complete, got %q, %v", body, err)
+	}
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("expected 1 load, got %d", got)
+	}
+}
@@ -1,31 +1,354 @@
 package langfuse
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+)
+
 type clientFactoryImpl struct {
 	host        string
 	adminApiKey string
+	httpClient  *http.Client
+	userAgent   string
+	limiters    *limiterRegistry
+	cache       *responseCache
+
+	adminApiKeyUnknown             bool
+	defaultOrganizationCredentials Credentials
+	defaultProjectCredentials      Credentials
+	credentialSets                 map[string]CredentialSet
+	credentialSetsUnknown          bool
+	defaultMetadata                map[string]string
+	defaultDeletionProtection      bool
+	readOnly                       bool
 }
 
 type ClientFactory interface {
 	NewAdminClient() AdminClient
-	NewOrganizationClient(publicKey, privateKey string) OrganizationClient
-	NewLlmConnectionsClient(publicKey, privateKey string) LlmConnectionsClient
+	// HasAdminAPIKey reports whether the factory has an admin API key. Without one, every request of its admin
+	// clients is rejected by the server.
+	HasAdminAPIKey() bool
+	// AdminAPIKeyUnknown reports whether the admin API key is configured from values not known while planning.
+	// The requests of its admin clients then fail without being sent.
+	AdminAPIKeyUnknown() bool
+	// NewOrganizationClient and NewLlmConnectionsClient use the given keys when they are set. Otherwise they
+	// use the keys of the named credential set or, without a name, the factory's defaults. The requests of a
+	// client fail when the named set is unknown or has no keys for its scope.
+	NewOrganizationClient(credentialSet, publicKey, privateKey string) OrganizationClient
+	NewLlmConnectionsClient(credentialSet, publicKey, privateKey string) LlmConnectionsClient
+	// DefaultOrganizationCredentials and DefaultProjectCredentials are the keys used by clients created
+	// without any. They are zero when no default is configured.
+	DefaultOrganizationCredentials() Credentials
+	DefaultProjectCredentials() Credentials
+	// CredentialSet returns the named credential set, if the factory has one. While the credential sets are
+	// not known, any name is reported as a set whose keys are not known yet.
+	CredentialSet(name string) (CredentialSet, bool)
+	// DefaultMetadata is merged into the metadata of the organizations and projects. It is nil when no default
+	// is configured.
+	DefaultMetadata() map[string]string
+	// DefaultDeletionProtection is the deletion protection of the organizations and projects that do not set
+	// their own.
+	DefaultDeletionProtection() bool
+	// Health calls the unauthenticated health endpoint of the host.
+	Health(ctx context.Context) (*HealthStatus, error)
+	// ServerInfo describes the host's deployment. It is derived from the host, without contacting it.
+	ServerInfo() *ServerInfo
+}
+
+// ClientFactoryOption customises the clients created by a ClientFactory.
+type ClientFactoryOption func(*clientFactoryOptions)
+
+type clientFactoryOptions struct {
+	transport   http.RoundTripper
+	timeout     time.Duration
+	middlewares []Middleware
+	retryConfig RetryConfig
+	userAgent   string
+	rateLimit   RateLimitConfig
+
+	adminApiKeyUnknown             bool
+	defaultOrganizationCredentials Credentials
+	defaultProjectCredentials      Credentials
+	credentialSets                 map[string]CredentialSet
+	credentialSetsUnknown          bool
+	defaultMetadata                map[string]string
+	defaultDeletionProtection      bool
+	readOnly                       bool
+}
+
+// Credentials are the public and secret keys of an organization or project API key.
+type Credentials struct {
+	PublicKey string
+	SecretKey string
+	// Unknown is set for keys configured from values not known while planning, such as the outputs of
+	// other resources. They are known once the provider is configured again for the apply.
+	Unknown bool
+}
+
+// IsZero reports whether neither key is set, nor expected once the keys are known.
+func (c Credentials) IsZero() bool {
+	return c.PublicKey == "" && c.SecretKey == "" && !c.Unknown
+}
+
+// CredentialSet holds the organization and project keys selected together by name. Either may be zero.
+type CredentialSet struct {
+	Organization Credentials
+	Project      Credentials
+}
+
+// WithTransport replaces the base transport that sends requests over the wire.
+func WithTransport(transport http.RoundTripper) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout bounds each attempt of a request. Zero disables the timeout.
+func WithTimeout(timeout time.Duration) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithMiddleware adds middlewares around the base transport. The first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithRetryConfig overrides the retry behaviour of every client created by the factory.
+func WithRetryConfig(config RetryConfig) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.retryConfig = config
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request, replacing DefaultUserAgent.
+func WithUserAgent(userAgent string) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRateLimit paces the requests of every client created by the factory. Clients using the same
+// credentials share one budget, whichever resource they were created for.
+func WithRateLimit(config RateLimitConfig) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.rateLimit = config
+	}
+}
+
+// WithDefaultOrganizationCredentials sets the keys used by NewOrganizationClient when it is called without any.
+func WithDefaultOrganizationCredentials(credentials Credentials) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.defaultOrganizationCredentials = credentials
+	}
+}
+
+// WithDefaultProjectCredentials sets the keys used by NewLlmConnectionsClient when it is called without any.
+func WithDefaultProjectCredentials(credentials Credentials) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.defaultProjectCredentials = credentials
+	}
+}
+
+// WithCredentialSets sets the named credential sets that clients can be created with.
+func WithCredentialSets(sets map[string]CredentialSet) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.credentialSets = maps.Clone(sets)
+	}
+}
+
+// WithUnknownCredentialSets marks the credential sets as configured from values not known while planning, such
+// as the outputs of other resources. They are known once the provider is configured again for the apply.
+func WithUnknownCredentialSets() ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.credentialSetsUnknown = true
+	}
+}
+
+// WithUnknownAdminAPIKey marks the admin API key as configured from values not known while planning, such as
+// the outputs of other resources. It is known once the provider is configured again for the apply.
+func WithUnknownAdminAPIKey() ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.adminApiKeyUnknown = true
+	}
+}
+
+// WithDefaultMetadata sets the metadata merged into the metadata of the organizations and projects.
+func WithDefaultMetadata(metadata map[string]string) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.defaultMetadata = maps.Clone(metadata)
+	}
 }
 
-func NewClientFactory(host, adminApiKey string) ClientFactory {
+// WithDefaultDeletionProtection sets the deletion protection of the organizations and projects that do not
+// set their own.
+func WithDefaultDeletionProtection(deletionProtection bool) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.defaultDeletionProtection = deletionProtection
+	}
+}
+
+// WithReadOnly makes every client of the factory refuse the requests that could change the server, such as
+// POST, PUT, PATCH and DELETE, with an error wrapping ErrReadOnly instead of sending them.
+func WithReadOnly(readOnly bool) ClientFactoryOption {
+	return func(o *clientFactoryOptions) {
+		o.readOnly = readOnly
+	}
+}
+
+func NewClientFactory(host, adminApiKey string, opts ...ClientFactoryOption) ClientFactory {
+	options := clientFactoryOptions{
+		timeout:     DefaultRequestTimeout,
+		retryConfig: DefaultRetryConfig(),
+		userAgent:   DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.transport == nil {
+		options.transport = newDefaultTransport()
+	}
+
+	// A single http.Client is shared by every client the factory creates, so connection pools are reused
+	// across resources instead of being rebuilt for each of them.
 	return &clientFactoryImpl{
 		host:        host,
 		adminApiKey: adminApiKey,
+		httpClient: &http.Client{
+			Transport: buildTransportChain(options.transport, options.middlewares, options.timeout, options.retryConfig),
+		},
+		userAgent: options.userAgent,
+		limiters:  newLimiterRegistry(options.rateLimit),
+		cache:     newResponseCache(),
+
+		adminApiKeyUnknown:             options.adminApiKeyUnknown,
+		defaultOrganizationCredentials: options.defaultOrganizationCredentials,
+		defaultProjectCredentials:      options.defaultProjectCredentials,
+		credentialSets:                 options.credentialSets,
+		credentialSetsUnknown:          options.credentialSetsUnknown,
+		defaultMetadata:                options.defaultMetadata,
+		defaultDeletionProtection:      options.defaultDeletionProtection,
+		readOnly:                       options.readOnly,
 	}
 }
 
 func (cf *clientFactoryImpl) NewAdminClient() AdminClient {
-	return NewAdminClient(cf.host, cf.adminApiKey)
+	if cf.adminApiKeyUnknown {
+		return newAdminClient(cf.newAPIClient(unresolvedCredentials{err: errors.New("the admin API key of the provider is not known until the apply")}))
+	}
+	return newAdminClient(cf.newAPIClient(bearerAuth{token: cf.adminApiKey}, cf.adminApiKey))
 }
 
-func (cf *clientFactoryImpl) NewOrganizationClient(publicKey, privateKey string) OrganizationClient {
-	return NewOrganizationClient(cf.host, publicKey, privateKey)
+func (cf *clientFactoryImpl) HasAdminAPIKey() bool {
+	return cf.adminApiKey != ""
 }
 
-func (cf *clientFactoryImpl) NewLlmConnectionsClient(publicKey, privateKey string) LlmConnectionsClient {
-	return NewLlmConnectionsClient(cf.host, publicKey, privateKey)
+func (cf *clientFactoryImpl) AdminAPIKeyUnknown() bool {
+	return cf.adminApiKeyUnknown
+}
+
+func (cf *clientFactoryImpl) NewOrganizationClient(credentialSet, publicKey, privateKey string) OrganizationClient {
+	credentials, err := cf.resolveCredentials(Credentials{PublicKey: publicKey, SecretKey: privateKey}, credentialSet, "organization",
+		func(set CredentialSet) Credentials { return set.Organization }, cf.defaultOrganizationCredentials)
+	return newOrganizationClient(cf.newBasicAuthClient(credentials, err))
+}
+
+func (cf *clientFactoryImpl) NewLlmConnectionsClient(credentialSet, publicKey, privateKey string) LlmConnectionsClient {
+	credentials, err := cf.resolveCredentials(Credentials{PublicKey: publicKey, SecretKey: privateKey}, credentialSet, "project",
+		func(set CredentialSet) Credentials { return set.Project }, cf.defaultProjectCredentials)
+	return newLlmConnectionsClient(cf.newBasicAuthClient(credentials, err))
+}
+
+func (cf *clientFactoryImpl) DefaultOrganizationCredentials() Credentials {
+	return cf.defaultOrganizationCredentials
+}
+
+func (cf *clientFactoryImpl) DefaultProjectCredentials() Credentials {
+	return cf.defaultProjectCredentials
+}
+
+func (cf *clientFactoryImpl) CredentialSet(name string) (CredentialSet, bool) {
+	if set, ok := cf.credentialSets[name]; ok {
+		return set, true
+	}
+	if cf.credentialSetsUnknown {
+		return CredentialSet{Organization: Credentials{Unknown: true}, Project: Credentials{Unknown: true}}, true
+	}
+	return CredentialSet{}, false
+}
+
+func (cf *clientFactoryImpl) DefaultMetadata() map[string]string {
+	return cf.defaultMetadata
+}
+
+func (cf *clientFactoryImpl) DefaultDeletionProtection() bool {
+	return cf.defaultDeletionProtection
+}
+
+// resolveCredentials returns the explicit keys when they are set, then those of the named credential set for
+// the given scope, then the defaults. Keys that are not known yet cannot authenticate anything.
+func (cf *clientFactoryImpl) resolveCredentials(explicit Credentials, credentialSet, scope string, fromSet func(CredentialSet) Credentials, defaults Credentials) (Credentials, error) {
+	if !explicit.IsZero() {
+		return explicit, nil
+	}
+
+	credentials := defaults
+	if credentialSet != "" {
+		set, ok := cf.CredentialSet(credentialSet)
+		if !ok {
+			return Credentials{}, fmt.Errorf("unknown credential set %q", credentialSet)
+		}
+		credentials = fromSet(set)
+		if credentials.IsZero() {
+			return Credentials{}, fmt.Errorf("credential set %q has no %s keys", credentialSet, scope)
+		}
+	}
+
+	if credentials.Unknown {
+		return Credentials{}, fmt.Errorf("the %s keys of the provider are not known until the apply", scope)
+	}
+	return credentials, nil
+}
+
+func (cf *clientFactoryImpl) Health(ctx context.Context) (*HealthStatus, error) {
+	return getHealth(ctx, cf.newAPIClient(noAuth{}))
+}
+
+func (cf *clientFactoryImpl) ServerInfo() *ServerInfo {
+	return newServerInfo(cf.host)
+}
+
+// newAPIClient creates a client sharing the factory's HTTP client and cache, and the rate limit of the
+// given credentials.
+func (cf *clientFactoryImpl) newAPIClient(auth authStrategy, credentials ...string) *apiClient {
+	client := newAPIClient(cf.host, auth, cf.httpClient)
+	client.userAgent = cf.userAgent
+	client.limiter = cf.limiters.forCredentials(credentials...)
+	client.cache = cf.cache
+	client.credentialsKey = credentialsKey(credentials...)
+	client.readOnly = cf.readOnly
+	return client
+}
+
+// newBasicAuthClient creates a client authenticated with the given keys, or failing with err when they could
+// not be resolved.
+func (cf *clientFactoryImpl) newBasicAuthClient(credentials Credentials, err error) *apiClient {
+	if err != nil {
+		return cf.newAPIClient(unresolvedCredentials{err: err})
+	}
+	return cf.newAPIClient(basicAuth{publicKey: credentials.PublicKey, secretKey: credentials.SecretKey}, credentials.PublicKey, credentials.SecretKey)
+}
+
+// newDefaultHTTPClient is used by the standalone client constructors that are not created through a factory.
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: buildTransportChain(newDefaultTransport(), nil, DefaultRequestTimeout, DefaultRetryConfig()),
+	}
 }
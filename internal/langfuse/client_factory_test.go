@@ -0,0 +1,315 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientFactorySharesTransportAndAppliesAuth(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/admin/organizations":
+			if got := r.Header.Get("Authorization"); got != "Bearer admin-key" {
+				t.Errorf("unexpected admin Authorization header: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"organizations":[]}`))
+		case "/api/public/organizations/projects":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "pk-org" || pass != "sk-org" {
+				t.Errorf("unexpected organization basic auth: %q/%q", user, pass)
+			}
+			_, _ = w.Write([]byte(`{"projects":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var seen atomic.Int32
+	middleware := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			seen.Add(1)
+			return next.RoundTrip(req)
+		})
+	}
+
+	factory := NewClientFactory(server.URL, "admin-key", WithMiddleware(middleware)).(*clientFactoryImpl)
+
+	adminClient := factory.NewAdminClient().(*adminClientImpl)
+	orgClient := factory.NewOrganizationClient("", "pk-org", "sk-org").(*organizationClientImpl)
+	if adminClient.httpClient != orgClient.httpClient {
+		t.Fatalf("expected all clients of a factory to share the same http.Client")
+	}
+
+	ctx := context.Background()
+	if _, err := Collect(adminClient.ListOrganizations(ctx)); err != nil {
+		t.Fatalf("unexpected error listing organizations: %v", err)
+	}
+	if _, err := Collect(orgClient.ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+
+	if seen.Load() != 2 {
+		t.Fatalf("expected middleware to see 2 requests, got %d", seen.Load())
+	}
+}
+
+func TestClientFactoryUnknownAdminAPIKey(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"organizations":[]}`))
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "", WithUnknownAdminAPIKey())
+	if !factory.AdminAPIKeyUnknown() || factory.HasAdminAPIKey() {
+		t.Fatalf("expected an admin API key that is not known yet")
+	}
+	if _, err := Collect(factory.NewAdminClient().ListOrganizations(context.Background())); err == nil || !strings.Contains(err.Error(), "admin API key of the provider is not known") {
+		t.Errorf("expected the unknown admin API key to be reported, got %v", err)
+	}
+	if requests.Load() != 0 {
+		t.Errorf("expected no request with an unknown admin API key, got %d", requests.Load())
+	}
+}
+
+func TestBuildTransportChainOrdersMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	named := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+
+	transport := buildTransportChain(base, []Middleware{named("first"), named("second")}, 0, RetryConfig{})
+	req, _ := http.NewRequest(http.MethodGet, "http://langfuse.test", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(order) != 3 || order[0] != "first" || order[1] != "second" || order[2] != "base" {
+		t.Fatalf("unexpected middleware order: %v", order)
+	}
+}
+
+func TestClientFactorySetsUserAgent(t *testing.T) {
+	t.Parallel()
+
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"organizations":[]}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if _, err := Collect(NewClientFactory(server.URL, "admin-key").NewAdminClient().ListOrganizations(ctx)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	custom := NewClientFactory(server.URL, "admin-key", WithUserAgent("terraform-provider-langfuse/1.2.3 terraform/1.9.0"))
+	if _, err := Collect(custom.NewAdminClient().ListOrganizations(ctx)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(userAgents) != 2 || userAgents[0] != DefaultUserAgent || userAgents[1] != "terraform-provider-langfuse/1.2.3 terraform/1.9.0" {
+		t.Fatalf("unexpected User-Agent headers: %q", userAgents)
+	}
+}
+
+func TestClientFactoryFallsBackToDefaultCredentials(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		got = append(got, user+":"+pass)
+		switch r.URL.Path {
+		case "/api/public/organizations/projects":
+			_, _ = w.Write([]byte(`{"projects":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "",
+		WithDefaultOrganizationCredentials(Credentials{PublicKey: "pk-org", SecretKey: "sk-org"}),
+		WithDefaultProjectCredentials(Credentials{PublicKey: "pk-project", SecretKey: "sk-project"}),
+	)
+
+	ctx := context.Background()
+	if _, err := Collect(factory.NewOrganizationClient("", "", "").ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+	if _, err := Collect(factory.NewOrganizationClient("", "pk-explicit", "sk-explicit").ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+	if _, err := Collect(factory.NewLlmConnectionsClient("", "", "").ListLlmConnections(ctx)); err != nil {
+		t.Fatalf("unexpected error listing LLM connections: %v", err)
+	}
+
+	want := []string{"pk-org:sk-org", "pk-explicit:sk-explicit", "pk-project:sk-project"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected credentials sent: got %v, want %v", got, want)
+	}
+
+	// Defaults that are not known yet fail without sending anything, e.g. while refreshing before an apply.
+	unknown := NewClientFactory(server.URL, "",
+		WithDefaultOrganizationCredentials(Credentials{Unknown: true}),
+		WithDefaultProjectCredentials(Credentials{Unknown: true}),
+	)
+	if _, err := Collect(unknown.NewOrganizationClient("", "", "").ListProjects(ctx)); err == nil || !strings.Contains(err.Error(), "not known until the apply") {
+		t.Errorf("expected the unknown keys to be reported, got %v", err)
+	}
+	if _, err := Collect(unknown.NewLlmConnectionsClient("", "", "").ListLlmConnections(ctx)); err == nil || !strings.Contains(err.Error(), "project keys of the provider are not known") {
+		t.Errorf("expected the unknown project keys to be reported, got %v", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected no request with unknown credentials, got %v", got[len(want):])
+	}
+}
+
+func TestClientFactoryResolvesCredentialSets(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		got = append(got, user+":"+pass)
+		switch r.URL.Path {
+		case "/api/public/organizations/projects":
+			_, _ = w.Write([]byte(`{"projects":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "",
+		WithDefaultOrganizationCredentials(Credentials{PublicKey: "pk-default", SecretKey: "sk-default"}),
+		WithCredentialSets(map[string]CredentialSet{
+			"team-a": {
+				Organization: Credentials{PublicKey: "pk-org-a", SecretKey: "sk-org-a"},
+				Project:      Credentials{PublicKey: "pk-project-a", SecretKey: "sk-project-a"},
+			},
+			"team-b": {Organization: Credentials{PublicKey: "pk-org-b", SecretKey: "sk-org-b"}},
+		}),
+	)
+
+	ctx := context.Background()
+	if _, err := Collect(factory.NewOrganizationClient("team-a", "", "").ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+	if _, err := Collect(factory.NewLlmConnectionsClient("team-a", "", "").ListLlmConnections(ctx)); err != nil {
+		t.Fatalf("unexpected error listing LLM connections: %v", err)
+	}
+	if _, err := Collect(factory.NewOrganizationClient("team-b", "pk-explicit", "sk-explicit").ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+
+	want := []string{"pk-org-a:sk-org-a", "pk-project-a:sk-project-a", "pk-explicit:sk-explicit"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected credentials sent: got %v, want %v", got, want)
+	}
+
+	// Unresolved sets fail without sending anything, rather than falling back to the defaults.
+	if _, err := Collect(factory.NewLlmConnectionsClient("team-b", "", "").ListLlmConnections(ctx)); err == nil || !strings.Contains(err.Error(), `credential set "team-b" has no project keys`) {
+		t.Errorf("expected the missing project keys to be reported, got %v", err)
+	}
+	if _, err := Collect(factory.NewOrganizationClient("team-c", "", "").ListProjects(ctx)); err == nil || !strings.Contains(err.Error(), `unknown credential set "team-c"`) {
+		t.Errorf("expected the unknown set to be reported, got %v", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected no request with unresolved credentials, got %v", got[len(want):])
+	}
+
+	// While the credential sets are not known, any set is accepted for planning but cannot authenticate.
+	pending := NewClientFactory(server.URL, "", WithUnknownCredentialSets())
+	if set, ok := pending.CredentialSet("team-c"); !ok || !set.Organization.Unknown || !set.Project.Unknown {
+		t.Errorf("expected a set with unknown keys, got %+v, %t", set, ok)
+	}
+	if _, err := Collect(pending.NewOrganizationClient("team-c", "", "").ListProjects(ctx)); err == nil || !strings.Contains(err.Error(), "not known until the apply") {
+		t.Errorf("expected the unknown keys to be reported, got %v", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected no request with unknown credentials, got %v", got[len(want):])
+	}
+}
+
+func TestClientFactoryReadOnly(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.URL.Path {
+		case "/api/admin/organizations":
+			_, _ = w.Write([]byte(`{"organizations":[]}`))
+		case "/api/public/organizations/projects":
+			_, _ = w.Write([]byte(`{"projects":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "admin-key", WithReadOnly(true))
+	adminClient := factory.NewAdminClient()
+	organizationClient := factory.NewOrganizationClient("", "pk-org", "sk-org")
+	llmConnectionsClient := factory.NewLlmConnectionsClient("", "pk-project", "sk-project")
+
+	ctx := context.Background()
+	if _, err := Collect(adminClient.ListOrganizations(ctx)); err != nil {
+		t.Fatalf("unexpected error listing organizations: %v", err)
+	}
+	if _, err := Collect(organizationClient.ListProjects(ctx)); err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+	if _, err := Collect(llmConnectionsClient.ListLlmConnections(ctx)); err != nil {
+		t.Fatalf("unexpected error listing LLM connections: %v", err)
+	}
+
+	writes := map[string]func() error{
+		"CreateOrganization": func() error {
+			_, err := adminClient.CreateOrganization(ctx, &CreateOrganizationRequest{Name: "Acme"})
+			return err
+		},
+		"DeleteOrganization": func() error { return adminClient.DeleteOrganization(ctx, "org-123") },
+		"UpdateProject": func() error {
+			_, err := organizationClient.UpdateProject(ctx, "proj-123", &UpdateProjectRequest{Name: "web"})
+			return err
+		},
+		"UpsertLlmConnection": func() error {
+			_, err := llmConnectionsClient.UpsertLlmConnection(ctx, &UpsertLlmConnectionRequest{Provider: "openai"})
+			return err
+		},
+		"DeleteLlmConnection": func() error { return llmConnectionsClient.DeleteLlmConnection(ctx, "conn-123") },
+	}
+	for name, write := range writes {
+		if err := write(); !IsReadOnly(err) || !strings.Contains(err.Error(), "refusing to send") {
+			t.Errorf("%s: expected a read-only error, got %v", name, err)
+		}
+	}
+
+	if !slices.Equal(methods, []string{http.MethodGet, http.MethodGet, http.MethodGet}) {
+		t.Fatalf("expected only the GET requests to be sent, got %v", methods)
+	}
+}
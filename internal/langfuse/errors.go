@@ -0,0 +1,111 @@
+package langfuse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotFound is wrapped by every error reporting that an object does not exist, whether the API answered
+// with a 404 or the object was missing from a list response.
+var ErrNotFound = errors.New("not found")
+
+// ErrReadOnly is wrapped by the errors of the requests refused by a read-only client before being sent.
+var ErrReadOnly = errors.New("read-only mode is enabled")
+
+// APIError is returned when the Langfuse API answers with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Message is the error message extracted from the JSON response body, if any.
+	Message string
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+	return fmt.Sprintf("%s %s: request failed with status code %d, response body: %s", e.Method, e.Path, e.StatusCode, detail)
+}
+
+// Is makes errors.Is(err, ErrNotFound) hold for 404 responses.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Message:    parseErrorMessage(body),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+	return apiErr
+}
+
+// parseErrorMessage extracts the human-readable message from the error bodies returned by Langfuse,
+// which use either a "message" or an "error" field.
+func parseErrorMessage(body []byte) string {
+	var payload struct {
+		Message any `json:"message"`
+		Error   any `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	if message, ok := payload.Message.(string); ok && message != "" {
+		return message
+	}
+	if message, ok := payload.Error.(string); ok {
+		return message
+	}
+	return ""
+}
+
+// IsNotFound reports whether err means that the requested object does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsReadOnly reports whether err is a request refused by a read-only client.
+func IsReadOnly(err error) bool {
+	return errors.Is(err, ErrReadOnly)
+}
+
+// IsBadRequest reports whether the API rejected the request with 400 Bad Request.
+func IsBadRequest(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+// IsConflict reports whether the API rejected the request with 409 Conflict.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether the API rejected the credentials with 401 Unauthorized.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether the credentials lack permission for the request (403 Forbidden).
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether the API rejected the request with 429 Too Many Requests.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
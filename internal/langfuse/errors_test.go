@@ -0,0 +1,79 @@
+package langfuse
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecodeResponseReturnsAPIError(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Invalid credentials"}`)),
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/api/public/projects"}},
+	}
+
+	err := decodeResponse(resp, &struct{}{})
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Method != http.MethodGet || apiErr.Path != "/api/public/projects" {
+		t.Fatalf("unexpected API error fields: %+v", apiErr)
+	}
+	if apiErr.Message != "Invalid credentials" {
+		t.Fatalf("unexpected message. got %q, want %q", apiErr.Message, "Invalid credentials")
+	}
+	if !IsUnauthorized(err) || IsNotFound(err) {
+		t.Fatalf("unexpected classification of 401 error")
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	t.Parallel()
+
+	notFound := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusNotFound})
+	if !IsNotFound(notFound) {
+		t.Fatalf("expected wrapped 404 to be reported as not found")
+	}
+	if !IsNotFound(fmt.Errorf("%w: ID proj-1", ErrProjectNotFound)) {
+		t.Fatalf("expected missing list entry to be reported as not found")
+	}
+	if !IsBadRequest(&APIError{StatusCode: http.StatusBadRequest}) || IsBadRequest(notFound) {
+		t.Fatalf("expected only 400 to be reported as bad request")
+	}
+	if !IsConflict(&APIError{StatusCode: http.StatusConflict}) {
+		t.Fatalf("expected 409 to be reported as conflict")
+	}
+	if !IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests}) {
+		t.Fatalf("expected 429 to be reported as rate limited")
+	}
+	if !IsReadOnly(fmt.Errorf("refusing to send DELETE api/public/projects/proj-1: %w", ErrReadOnly)) || IsReadOnly(notFound) {
+		t.Fatalf("expected only refused requests to be reported as read-only")
+	}
+	if IsNotFound(fmt.Errorf("network error")) {
+		t.Fatalf("expected untyped error not to be reported as not found")
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`{"message":"from message"}`:                  "from message",
+		`{"error":"from error"}`:                      "from error",
+		`{"error":{"issues":[]},"message":"details"}`: "details",
+		`not json`: "",
+	}
+	for body, want := range cases {
+		if got := parseErrorMessage([]byte(body)); got != want {
+			t.Errorf("parseErrorMessage(%q) = %q, want %q", body, got, want)
+		}
+	}
+}
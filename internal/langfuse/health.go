@@ -0,0 +1,32 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+)
+
+// HealthStatus is returned by the unauthenticated health endpoint of a Langfuse instance.
+type HealthStatus struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// noAuth is used for the endpoints that do not require credentials.
+type noAuth struct{}
+
+func (noAuth) authenticate(*http.Request) error { return nil }
+
+// getHealth reports whether the instance is reachable and able to serve requests. It is never cached.
+func getHealth(ctx context.Context, client *apiClient) (*HealthStatus, error) {
+	resp, err := client.makeRequest(ctx, http.MethodGet, "api/public/health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var health HealthStatus
+	if err := decodeResponse(resp, &health); err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
@@ -0,0 +1,163 @@
+package langfusetest
+
+import (
+	"maps"
+	"net/http"
+)
+
+type organizationJSON struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+type organizationRequest struct {
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// apiKeyJSON is an API key as listed, without its secret, which is only returned once when created.
+type apiKeyJSON struct {
+	ID               string  `json:"id"`
+	PublicKey        string  `json:"publicKey"`
+	SecretKey        string  `json:"secretKey,omitempty"`
+	DisplaySecretKey string  `json:"displaySecretKey"`
+	Note             *string `json:"note"`
+}
+
+func (o *organization) toJSON() organizationJSON {
+	return organizationJSON{ID: o.id, Name: o.name, Metadata: o.metadata}
+}
+
+func (k *apiKey) toJSON(withSecret bool) apiKeyJSON {
+	key := apiKeyJSON{
+		ID:               k.id,
+		PublicKey:        k.publicKey,
+		DisplaySecretKey: "sk-lf-..." + k.secretKey[len(k.secretKey)-4:],
+		Note:             k.note,
+	}
+	if withSecret {
+		key.SecretKey = k.secretKey
+	}
+	return key
+}
+
+func (s *Server) organization(w http.ResponseWriter, r *http.Request) *organization {
+	org := find(s.organizations, r.PathValue("organizationId"))
+	if org == nil {
+		writeError(w, http.StatusNotFound, "error", "Organization not found")
+	}
+	return org
+}
+
+// listOrganizations returns every organization at once, like the admin API, which does not paginate.
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations := make([]organizationJSON, 0, len(s.organizations))
+	for _, org := range s.organizations {
+		organizations = append(organizations, org.toJSON())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"organizations": organizations})
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request) {
+	var request organizationRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "error", "Invalid request body: name is required")
+		return
+	}
+
+	org := &organization{id: s.newID("org"), name: request.Name, metadata: maps.Clone(request.Metadata)}
+	s.organizations = append(s.organizations, org)
+
+	writeJSON(w, http.StatusCreated, org.toJSON())
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request) {
+	if org := s.organization(w, r); org != nil {
+		writeJSON(w, http.StatusOK, org.toJSON())
+	}
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request) {
+	org := s.organization(w, r)
+	if org == nil {
+		return
+	}
+	var request organizationRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "error", "Invalid request body: name is required")
+		return
+	}
+
+	org.name = request.Name
+	org.metadata = maps.Clone(request.Metadata)
+
+	writeJSON(w, http.StatusOK, org.toJSON())
+}
+
+// deleteOrganization refuses to delete an organization that still has projects, like the admin API.
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	org := s.organization(w, r)
+	if org == nil {
+		return
+	}
+	if len(org.projects) > 0 {
+		writeError(w, http.StatusBadRequest, "error", "Cannot delete organization with existing projects")
+		return
+	}
+
+	s.deleteApiKeys(org.apiKeys...)
+	remove(&s.organizations, org.id)
+
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (s *Server) listOrganizationApiKeys(w http.ResponseWriter, r *http.Request) {
+	org := s.organization(w, r)
+	if org == nil {
+		return
+	}
+
+	keys := make([]apiKeyJSON, 0, len(org.apiKeys))
+	for _, key := range org.apiKeys {
+		keys = append(keys, key.toJSON(false))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"apiKeys": keys})
+}
+
+func (s *Server) createOrganizationApiKey(w http.ResponseWriter, r *http.Request) {
+	org := s.organization(w, r)
+	if org == nil {
+		return
+	}
+
+	key := s.newApiKey(org, nil, nil)
+	org.apiKeys = append(org.apiKeys, key)
+
+	writeJSON(w, http.StatusCreated, key.toJSON(true))
+}
+
+func (s *Server) deleteOrganizationApiKey(w http.ResponseWriter, r *http.Request) {
+	org := s.organization(w, r)
+	if org == nil {
+		return
+	}
+	key := find(org.apiKeys, r.PathValue("apiKeyId"))
+	if key == nil {
+		writeError(w, http.StatusNotFound, "error", "API key not found")
+		return
+	}
+
+	s.deleteApiKeys(key)
+	remove(&org.apiKeys, key.id)
+
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
@@ -0,0 +1,131 @@
+package langfusetest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+var validAdapters = []string{"anthropic", "openai", "azure", "bedrock", "google-vertex-ai", "google-ai-studio"}
+
+type llmConnection struct {
+	id                string
+	provider          string
+	adapter           string
+	secretKey         string
+	baseURL           string
+	customModels      []string
+	withDefaultModels bool
+	extraHeaders      map[string]string
+	config            map[string]any
+	createdAt         string
+	updatedAt         string
+}
+
+// llmConnectionJSON never includes the secret key nor the values of the extra headers.
+type llmConnectionJSON struct {
+	ID                string         `json:"id"`
+	Provider          string         `json:"provider"`
+	Adapter           string         `json:"adapter"`
+	DisplaySecretKey  string         `json:"displaySecretKey"`
+	BaseURL           *string        `json:"baseURL"`
+	CustomModels      []string       `json:"customModels"`
+	WithDefaultModels bool           `json:"withDefaultModels"`
+	ExtraHeaderKeys   []string       `json:"extraHeaderKeys"`
+	Config            map[string]any `json:"config"`
+	CreatedAt         string         `json:"createdAt"`
+	UpdatedAt         string         `json:"updatedAt"`
+}
+
+type llmConnectionRequest struct {
+	Adapter           string            `json:"adapter"`
+	Provider          string            `json:"provider"`
+	SecretKey         string            `json:"secretKey"`
+	BaseURL           string            `json:"baseURL"`
+	Config            map[string]any    `json:"config"`
+	CustomModels      []string          `json:"customModels"`
+	ExtraHeaders      map[string]string `json:"extraHeaders"`
+	WithDefaultModels *bool             `json:"withDefaultModels"`
+}
+
+func (c *llmConnection) toJSON() llmConnectionJSON {
+	conn := llmConnectionJSON{
+		ID:                c.id,
+		Provider:          c.provider,
+		Adapter:           c.adapter,
+		DisplaySecretKey:  "..." + c.secretKey[max(len(c.secretKey)-4, 0):],
+		CustomModels:      slices.Clone(c.customModels),
+		WithDefaultModels: c.withDefaultModels,
+		ExtraHeaderKeys:   slices.Sorted(maps.Keys(c.extraHeaders)),
+		Config:            c.config,
+		CreatedAt:         c.createdAt,
+		UpdatedAt:         c.updatedAt,
+	}
+	if conn.CustomModels == nil {
+		conn.CustomModels = []string{}
+	}
+	if c.baseURL != "" {
+		conn.BaseURL = &c.baseURL
+	}
+	return conn
+}
+
+// listLlmConnections is paginated, unlike most list endpoints used by the provider.
+func (s *Server) listLlmConnections(w http.ResponseWriter, r *http.Request, proj *project) {
+	page, meta := paginate(r, proj.llmConnections)
+
+	connections := make([]llmConnectionJSON, 0, len(page))
+	for _, conn := range page {
+		connections = append(connections, conn.toJSON())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": connections, "meta": meta})
+}
+
+// upsertLlmConnection creates the connection of the requested provider, or replaces it when the project
+// already has one, answering 201 and 200 respectively.
+func (s *Server) upsertLlmConnection(w http.ResponseWriter, r *http.Request, proj *project) {
+	var request llmConnectionRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.Provider == "" || request.SecretKey == "" {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: provider and secretKey are required")
+		return
+	}
+	if !slices.Contains(validAdapters, request.Adapter) {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: invalid adapter "+request.Adapter)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	status := http.StatusOK
+	i := slices.IndexFunc(proj.llmConnections, func(c *llmConnection) bool { return c.provider == request.Provider })
+	if i < 0 {
+		proj.llmConnections = append(proj.llmConnections, &llmConnection{id: s.newID("llm-connection"), provider: request.Provider, createdAt: now})
+		i = len(proj.llmConnections) - 1
+		status = http.StatusCreated
+	}
+
+	conn := proj.llmConnections[i]
+	conn.adapter = request.Adapter
+	conn.secretKey = request.SecretKey
+	conn.baseURL = request.BaseURL
+	conn.customModels = slices.Clone(request.CustomModels)
+	conn.withDefaultModels = request.WithDefaultModels == nil || *request.WithDefaultModels
+	conn.extraHeaders = maps.Clone(request.ExtraHeaders)
+	conn.config = request.Config
+	conn.updatedAt = now
+
+	writeJSON(w, status, conn.toJSON())
+}
+
+func (s *Server) deleteLlmConnection(w http.ResponseWriter, r *http.Request, proj *project) {
+	if !remove(&proj.llmConnections, r.PathValue("connectionId")) {
+		writeError(w, http.StatusNotFound, "message", "LLM connection not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "LLM connection deleted successfully"})
+}
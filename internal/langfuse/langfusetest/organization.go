@@ -0,0 +1,351 @@
+package langfusetest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// validRoles are the roles of organization and project members.
+var validRoles = []string{"OWNER", "ADMIN", "MEMBER", "VIEWER", "NONE"}
+
+type projectJSON struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	RetentionDays *int32            `json:"retentionDays,omitempty"`
+	Metadata      map[string]string `json:"metadata"`
+}
+
+type projectRequest struct {
+	Name      string            `json:"name"`
+	Retention int32             `json:"retention"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+type membershipJSON struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+type membershipRequest struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+type scimUserJSON struct {
+	Schemas  []string    `json:"schemas"`
+	ID       string      `json:"id"`
+	UserName string      `json:"userName"`
+	Emails   []scimEmail `json:"emails"`
+	Active   bool        `json:"active"`
+}
+
+type scimUserRequest struct {
+	UserName string      `json:"userName"`
+	Emails   []scimEmail `json:"emails"`
+}
+
+// toJSON leaves out the retention unless asked for, since the project list does not return it.
+func (p *project) toJSON(withRetention bool) projectJSON {
+	proj := projectJSON{ID: p.id, Name: p.name, Metadata: p.metadata}
+	if withRetention {
+		proj.RetentionDays = &p.retentionDays
+	}
+	return proj
+}
+
+func (m *member) toJSON() membershipJSON {
+	return membershipJSON{UserID: m.user.id, Role: m.role, Email: m.user.email, Name: m.user.name}
+}
+
+// project returns the project of the path, which must belong to the authenticated organization.
+func (s *Server) project(w http.ResponseWriter, r *http.Request, org *organization) *project {
+	proj := find(org.projects, r.PathValue("projectId"))
+	if proj == nil {
+		writeError(w, http.StatusNotFound, "message", "Project not found")
+	}
+	return proj
+}
+
+func validRetention(w http.ResponseWriter, retention int32) bool {
+	if retention != 0 && retention < 3 {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: retention must be 0 or at least 3 days")
+		return false
+	}
+	return true
+}
+
+func validRole(w http.ResponseWriter, role string) bool {
+	if !slices.Contains(validRoles, role) {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: invalid role "+role)
+		return false
+	}
+	return true
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, org *organization) {
+	projects := make([]projectJSON, 0, len(org.projects))
+	for _, proj := range org.projects {
+		projects = append(projects, proj.toJSON(false))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"projects": projects})
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, org *organization) {
+	var request projectRequest
+	if !decodeBody(w, r, &request) || !validRetention(w, request.Retention) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: name is required")
+		return
+	}
+	if slices.ContainsFunc(org.projects, func(p *project) bool { return p.name == request.Name }) {
+		writeError(w, http.StatusConflict, "message", "A project with this name already exists in the organization")
+		return
+	}
+
+	proj := &project{
+		id:            s.newID("project"),
+		organization:  org,
+		name:          request.Name,
+		retentionDays: request.Retention,
+		metadata:      maps.Clone(request.Metadata),
+	}
+	org.projects = append(org.projects, proj)
+
+	writeJSON(w, http.StatusCreated, proj.toJSON(true))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+	var request projectRequest
+	if !decodeBody(w, r, &request) || !validRetention(w, request.Retention) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request data: name is required")
+		return
+	}
+
+	proj.name = request.Name
+	proj.retentionDays = request.Retention
+	proj.metadata = maps.Clone(request.Metadata)
+
+	writeJSON(w, http.StatusOK, proj.toJSON(true))
+}
+
+// deleteProject answers 202 Accepted, since the real API deletes projects asynchronously.
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+
+	s.deleteApiKeys(proj.apiKeys...)
+	remove(&org.projects, proj.id)
+
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"success": true,
+		"message": "Project deletion has been initiated and is being processed asynchronously",
+	})
+}
+
+func (s *Server) listProjectApiKeys(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+
+	keys := make([]apiKeyJSON, 0, len(proj.apiKeys))
+	for _, key := range proj.apiKeys {
+		keys = append(keys, key.toJSON(false))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"apiKeys": keys})
+}
+
+func (s *Server) createProjectApiKey(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+	var request struct {
+		Note *string `json:"note"`
+	}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	key := s.newApiKey(nil, proj, request.Note)
+	proj.apiKeys = append(proj.apiKeys, key)
+
+	writeJSON(w, http.StatusCreated, key.toJSON(true))
+}
+
+func (s *Server) deleteProjectApiKey(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+	key := find(proj.apiKeys, r.PathValue("apiKeyId"))
+	if key == nil {
+		writeError(w, http.StatusNotFound, "message", "API key not found")
+		return
+	}
+
+	s.deleteApiKeys(key)
+	remove(&proj.apiKeys, key.id)
+
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+// listMemberships returns the members without a membership ID, like the public API.
+func (s *Server) listMemberships(w http.ResponseWriter, r *http.Request, org *organization) {
+	memberships := make([]membershipJSON, 0, len(org.members))
+	for _, m := range org.members {
+		memberships = append(memberships, m.toJSON())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"memberships": memberships})
+}
+
+func (s *Server) updateMembership(w http.ResponseWriter, r *http.Request, org *organization) {
+	var request membershipRequest
+	if !decodeBody(w, r, &request) || !validRole(w, request.Role) {
+		return
+	}
+	m := find(org.members, request.UserID)
+	if m == nil {
+		writeError(w, http.StatusNotFound, "message", "User is not a member of the organization")
+		return
+	}
+
+	m.role = request.Role
+
+	writeJSON(w, http.StatusOK, m.toJSON())
+}
+
+// removeMember answers success false along with a message saying that the member was deleted, a quirk of
+// the public API that the client tolerates.
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	var request membershipRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if !remove(&org.members, request.UserID) {
+		writeError(w, http.StatusNotFound, "message", "User is not a member of the organization")
+		return
+	}
+	for _, proj := range org.projects {
+		remove(&proj.members, request.UserID)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"success": false, "message": "Membership deleted successfully"})
+}
+
+// createSCIMUser creates the user, or reuses an existing user with the same email, and adds it to the
+// organization without a role.
+func (s *Server) createSCIMUser(w http.ResponseWriter, r *http.Request, org *organization) {
+	var request scimUserRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.UserName == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"detail": "userName is required", "status": 400})
+		return
+	}
+	email := request.UserName
+	for _, e := range request.Emails {
+		if e.Primary {
+			email = e.Value
+		}
+	}
+
+	u := s.userByEmail(email)
+	if u != nil && find(org.members, u.id) != nil {
+		writeJSON(w, http.StatusConflict, map[string]any{"detail": "User with this email already exists in the organization", "status": 409})
+		return
+	}
+	if u == nil {
+		u = &user{id: s.newID("user"), email: email, name: request.UserName}
+		s.users = append(s.users, u)
+	}
+	org.members = append(org.members, &member{user: u, role: "NONE"})
+
+	writeJSON(w, http.StatusCreated, scimUserJSON{
+		Schemas:  []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		ID:       u.id,
+		UserName: u.name,
+		Emails:   []scimEmail{{Value: u.email, Primary: true}},
+		Active:   true,
+	})
+}
+
+func (s *Server) listProjectMemberships(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+
+	memberships := make([]membershipJSON, 0, len(proj.members))
+	for _, m := range proj.members {
+		memberships = append(memberships, m.toJSON())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"memberships": memberships})
+}
+
+// upsertProjectMembership only accepts members of the project's organization.
+func (s *Server) upsertProjectMembership(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+	var request membershipRequest
+	if !decodeBody(w, r, &request) || !validRole(w, request.Role) {
+		return
+	}
+	orgMember := find(org.members, request.UserID)
+	if orgMember == nil {
+		writeError(w, http.StatusNotFound, "message", "User is not a member of the organization")
+		return
+	}
+
+	m := find(proj.members, request.UserID)
+	if m == nil {
+		m = &member{user: orgMember.user}
+		proj.members = append(proj.members, m)
+	}
+	m.role = request.Role
+
+	writeJSON(w, http.StatusOK, m.toJSON())
+}
+
+func (s *Server) deleteProjectMembership(w http.ResponseWriter, r *http.Request, org *organization) {
+	proj := s.project(w, r, org)
+	if proj == nil {
+		return
+	}
+	var request membershipRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if !remove(&proj.members, request.UserID) {
+		writeError(w, http.StatusNotFound, "message", "Project membership not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "message": "Project membership deleted successfully"})
+}
@@ -0,0 +1,339 @@
+// Package langfusetest provides an in-memory fake of the Langfuse API for tests.
+//
+// The fake serves the admin, organization, project, membership, SCIM and LLM connection endpoints used by
+// the provider over a real HTTP server, so that tests exercise the clients' URL building, authentication
+// and JSON decoding instead of mocking them. It reproduces the quirks of the real API that the clients
+// work around, such as project lists without retention days, organization memberships without an ID, and
+// member removals answering success false with a "deleted" message.
+package langfusetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// DefaultVersion is the version reported by the health endpoint.
+const DefaultVersion = "3.120.0"
+
+// DefaultAdminKey is the admin API key accepted by a new Server.
+const DefaultAdminKey = "fake-admin-key"
+
+// Server is a fake Langfuse instance keeping its state in memory. Its URL is the host to configure.
+type Server struct {
+	*httptest.Server
+
+	// AdminKey is the bearer token accepted by the admin API.
+	AdminKey string
+
+	mu            sync.Mutex
+	version       string
+	nextID        int
+	organizations []*organization
+	users         []*user
+	apiKeys       map[string]*apiKey
+}
+
+type organization struct {
+	id       string
+	name     string
+	metadata map[string]string
+	apiKeys  []*apiKey
+	projects []*project
+	members  []*member
+}
+
+type project struct {
+	id             string
+	organization   *organization
+	name           string
+	retentionDays  int32
+	metadata       map[string]string
+	apiKeys        []*apiKey
+	members        []*member
+	llmConnections []*llmConnection
+}
+
+// apiKey belongs either to an organization or to a project.
+type apiKey struct {
+	id           string
+	publicKey    string
+	secretKey    string
+	note         *string
+	organization *organization
+	project      *project
+}
+
+type user struct {
+	id    string
+	email string
+	name  string
+}
+
+type member struct {
+	user *user
+	role string
+}
+
+// NewServer starts a fake Langfuse instance that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		AdminKey: DefaultAdminKey,
+		version:  DefaultVersion,
+		apiKeys:  make(map[string]*apiKey),
+	}
+	s.Server = httptest.NewServer(s.routes())
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// SetVersion changes the version reported by the health endpoint.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = version
+}
+
+// Exists reports whether an organization, project, API key or LLM connection with the given ID exists.
+func (s *Server) Exists(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, org := range s.organizations {
+		if org.id == id || slices.ContainsFunc(org.apiKeys, hasID[*apiKey](id)) {
+			return true
+		}
+		for _, proj := range org.projects {
+			if proj.id == id || slices.ContainsFunc(proj.apiKeys, hasID[*apiKey](id)) ||
+				slices.ContainsFunc(proj.llmConnections, hasID[*llmConnection](id)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/public/health", s.locked(s.health))
+
+	mux.HandleFunc("GET /api/admin/organizations", s.adminScoped(s.listOrganizations))
+	mux.HandleFunc("POST /api/admin/organizations", s.adminScoped(s.createOrganization))
+	mux.HandleFunc("GET /api/admin/organizations/{organizationId}", s.adminScoped(s.getOrganization))
+	mux.HandleFunc("PUT /api/admin/organizations/{organizationId}", s.adminScoped(s.updateOrganization))
+	mux.HandleFunc("DELETE /api/admin/organizations/{organizationId}", s.adminScoped(s.deleteOrganization))
+	mux.HandleFunc("GET /api/admin/organizations/{organizationId}/apiKeys", s.adminScoped(s.listOrganizationApiKeys))
+	mux.HandleFunc("POST /api/admin/organizations/{organizationId}/apiKeys", s.adminScoped(s.createOrganizationApiKey))
+	mux.HandleFunc("DELETE /api/admin/organizations/{organizationId}/apiKeys/{apiKeyId}", s.adminScoped(s.deleteOrganizationApiKey))
+
+	mux.HandleFunc("GET /api/public/organizations/projects", s.organizationScoped(s.listProjects))
+	mux.HandleFunc("POST /api/public/projects", s.organizationScoped(s.createProject))
+	mux.HandleFunc("PUT /api/public/projects/{projectId}", s.organizationScoped(s.updateProject))
+	mux.HandleFunc("DELETE /api/public/projects/{projectId}", s.organizationScoped(s.deleteProject))
+	mux.HandleFunc("GET /api/public/projects/{projectId}/apiKeys", s.organizationScoped(s.listProjectApiKeys))
+	mux.HandleFunc("POST /api/public/projects/{projectId}/apiKeys", s.organizationScoped(s.createProjectApiKey))
+	mux.HandleFunc("DELETE /api/public/projects/{projectId}/apiKeys/{apiKeyId}", s.organizationScoped(s.deleteProjectApiKey))
+	mux.HandleFunc("GET /api/public/organizations/memberships", s.organizationScoped(s.listMemberships))
+	mux.HandleFunc("PUT /api/public/organizations/memberships", s.organizationScoped(s.updateMembership))
+	mux.HandleFunc("DELETE /api/public/organizations/memberships", s.organizationScoped(s.removeMember))
+	mux.HandleFunc("POST /api/public/scim/Users", s.organizationScoped(s.createSCIMUser))
+	mux.HandleFunc("GET /api/public/projects/{projectId}/memberships", s.organizationScoped(s.listProjectMemberships))
+	mux.HandleFunc("PUT /api/public/projects/{projectId}/memberships", s.organizationScoped(s.upsertProjectMembership))
+	mux.HandleFunc("DELETE /api/public/projects/{projectId}/memberships", s.organizationScoped(s.deleteProjectMembership))
+
+	mux.HandleFunc("GET /api/public/llm-connections", s.projectScoped(s.listLlmConnections))
+	mux.HandleFunc("PUT /api/public/llm-connections", s.projectScoped(s.upsertLlmConnection))
+	mux.HandleFunc("DELETE /api/public/llm-connections/{connectionId}", s.projectScoped(s.deleteLlmConnection))
+
+	return mux
+}
+
+// locked serialises the handlers, so that each request sees and leaves a consistent state.
+func (s *Server) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		handler(w, r)
+	}
+}
+
+// adminScoped accepts the admin API key as a bearer token, like the admin API of a self-hosted instance.
+func (s *Server) adminScoped(handler http.HandlerFunc) http.HandlerFunc {
+	return s.locked(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.AdminKey {
+			writeError(w, http.StatusUnauthorized, "error", "Unauthorized: invalid admin API key")
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// organizationScoped accepts the API keys of an organization with basic authentication.
+func (s *Server) organizationScoped(handler func(http.ResponseWriter, *http.Request, *organization)) http.HandlerFunc {
+	return s.locked(func(w http.ResponseWriter, r *http.Request) {
+		key := s.authenticate(w, r)
+		if key == nil {
+			return
+		}
+		if key.organization == nil {
+			writeError(w, http.StatusForbidden, "message", "Organization-scoped API key required for this operation")
+			return
+		}
+		handler(w, r, key.organization)
+	})
+}
+
+// projectScoped accepts the API keys of a project with basic authentication.
+func (s *Server) projectScoped(handler func(http.ResponseWriter, *http.Request, *project)) http.HandlerFunc {
+	return s.locked(func(w http.ResponseWriter, r *http.Request) {
+		key := s.authenticate(w, r)
+		if key == nil {
+			return
+		}
+		if key.project == nil {
+			writeError(w, http.StatusForbidden, "message", "Project-scoped API key required for this operation")
+			return
+		}
+		handler(w, r, key.project)
+	})
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) *apiKey {
+	publicKey, secretKey, ok := r.BasicAuth()
+	key := s.apiKeys[publicKey]
+	if !ok || key == nil || key.secretKey != secretKey {
+		writeError(w, http.StatusUnauthorized, "message", "Invalid credentials. Confirm that you've configured the correct host.")
+		return nil
+	}
+	return key
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "OK", "version": s.version})
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *Server) newApiKey(org *organization, proj *project, note *string) *apiKey {
+	id := s.newID("key")
+	key := &apiKey{
+		id:           id,
+		publicKey:    "pk-lf-" + id,
+		secretKey:    "sk-lf-" + id,
+		note:         note,
+		organization: org,
+		project:      proj,
+	}
+	s.apiKeys[key.publicKey] = key
+	return key
+}
+
+// deleteApiKeys revokes keys, whose owner has been deleted or which have been deleted themselves.
+func (s *Server) deleteApiKeys(keys ...*apiKey) {
+	for _, key := range keys {
+		delete(s.apiKeys, key.publicKey)
+	}
+}
+
+func (s *Server) userByEmail(email string) *user {
+	for _, u := range s.users {
+		if strings.EqualFold(u.email, email) {
+			return u
+		}
+	}
+	return nil
+}
+
+type identified interface {
+	identifier() string
+}
+
+func (o *organization) identifier() string  { return o.id }
+func (p *project) identifier() string       { return p.id }
+func (k *apiKey) identifier() string        { return k.id }
+func (c *llmConnection) identifier() string { return c.id }
+func (m *member) identifier() string        { return m.user.id }
+
+func hasID[T identified](id string) func(T) bool {
+	return func(item T) bool { return item.identifier() == id }
+}
+
+// find returns the item with the given ID, or nil.
+func find[T identified](items []T, id string) T {
+	if i := slices.IndexFunc(items, hasID[T](id)); i >= 0 {
+		return items[i]
+	}
+	var zero T
+	return zero
+}
+
+// remove deletes the item with the given ID and reports whether it was found.
+func remove[T identified](items *[]T, id string) bool {
+	before := len(*items)
+	*items = slices.DeleteFunc(*items, hasID[T](id))
+	return len(*items) != before
+}
+
+type paginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+}
+
+// paginate returns the page of items selected by the page and limit query parameters, defaulting to the
+// first page of 50 items like the public API.
+func paginate[T any](r *http.Request, items []T) ([]T, paginationMeta) {
+	page := queryInt(r, "page", 1)
+	limit := queryInt(r, "limit", 50)
+
+	meta := paginationMeta{Page: page, Limit: limit, TotalItems: len(items), TotalPages: (len(items) + limit - 1) / limit}
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+
+	return items[start:end], meta
+}
+
+func queryInt(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value < 1 {
+		return fallback
+	}
+	return value
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, target any) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		writeError(w, http.StatusBadRequest, "message", "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError answers with an error message in field, since the admin API reports errors in "error" while
+// the public API uses "message".
+func writeError(w http.ResponseWriter, status int, field, message string) {
+	writeJSON(w, status, map[string]string{field: message})
+}
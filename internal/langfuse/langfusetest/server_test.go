@@ -0,0 +1,247 @@
+package langfusetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/langfusetest"
+)
+
+func TestServerAdminWorkflow(t *testing.T) {
+	t.Parallel()
+
+	server := langfusetest.NewServer(t)
+	factory := langfuse.NewClientFactory(server.URL, server.AdminKey)
+	admin := factory.NewAdminClient()
+	ctx := context.Background()
+
+	health, err := factory.Health(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error checking the health of the server: %v", err)
+	}
+	if health.Version != langfusetest.DefaultVersion {
+		t.Errorf("unexpected version %s", health.Version)
+	}
+
+	org, err := admin.CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{Name: "acme", Metadata: map[string]string{"team": "platform"}})
+	if err != nil {
+		t.Fatalf("unexpected error creating organization: %v", err)
+	}
+	org, err = admin.UpdateOrganization(ctx, org.ID, &langfuse.UpdateOrganizationRequest{Name: "acme-renamed"})
+	if err != nil {
+		t.Fatalf("unexpected error updating organization: %v", err)
+	}
+	if org.Name != "acme-renamed" || len(org.Metadata) != 0 {
+		t.Errorf("unexpected updated organization: %+v", org)
+	}
+	if got, err := admin.GetOrganization(ctx, org.ID); err != nil || got.Name != "acme-renamed" {
+		t.Errorf("GetOrganization() = %+v, %v", got, err)
+	}
+
+	key, err := admin.CreateOrganizationApiKey(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("unexpected error creating organization API key: %v", err)
+	}
+	if key.PublicKey == "" || key.SecretKey == "" {
+		t.Fatalf("expected the new key to carry its credentials: %+v", key)
+	}
+	listed, err := admin.GetOrganizationApiKey(ctx, org.ID, key.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting organization API key: %v", err)
+	}
+	if listed.SecretKey != "" {
+		t.Errorf("expected listed keys to hide their secret, got %q", listed.SecretKey)
+	}
+
+	// An organization with projects cannot be deleted.
+	if _, err := factory.NewOrganizationClient("", key.PublicKey, key.SecretKey).CreateProject(ctx, &langfuse.CreateProjectRequest{Name: "web"}); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	var apiErr *langfuse.APIError
+	if err := admin.DeleteOrganization(ctx, org.ID); !errors.As(err, &apiErr) || apiErr.Message != "Cannot delete organization with existing projects" {
+		t.Fatalf("expected the deletion to be refused, got %v", err)
+	}
+
+	if err := admin.DeleteOrganizationApiKey(ctx, org.ID, key.ID); err != nil {
+		t.Fatalf("unexpected error deleting organization API key: %v", err)
+	}
+	if _, err := admin.GetOrganizationApiKey(ctx, org.ID, key.ID); !langfuse.IsNotFound(err) {
+		t.Errorf("expected the deleted key to be missing, got %v", err)
+	}
+	if !server.Exists(org.ID) || server.Exists(key.ID) {
+		t.Errorf("unexpected server state after deleting the key")
+	}
+
+	// Revoked keys are rejected.
+	if _, err := langfuse.Collect(factory.NewOrganizationClient("", key.PublicKey, key.SecretKey).ListProjects(ctx)); !langfuse.IsUnauthorized(err) {
+		t.Errorf("expected the revoked key to be rejected, got %v", err)
+	}
+	if _, err := langfuse.Collect(langfuse.NewClientFactory(server.URL, "wrong").NewAdminClient().ListOrganizations(ctx)); !langfuse.IsUnauthorized(err) {
+		t.Errorf("expected a wrong admin key to be rejected, got %v", err)
+	}
+}
+
+func TestServerOrganizationWorkflow(t *testing.T) {
+	t.Parallel()
+
+	server := langfusetest.NewServer(t)
+	factory := langfuse.NewClientFactory(server.URL, server.AdminKey)
+	ctx := context.Background()
+	orgClient := newOrganizationClient(t, factory)
+
+	project, err := orgClient.CreateProject(ctx, &langfuse.CreateProjectRequest{Name: "web", RetentionDays: 30, Metadata: map[string]string{"env": "dev"}})
+	if err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	if project.RetentionDays != 30 {
+		t.Errorf("expected the created project to carry its retention, got %d", project.RetentionDays)
+	}
+	if _, err := orgClient.CreateProject(ctx, &langfuse.CreateProjectRequest{Name: "web"}); !langfuse.IsConflict(err) {
+		t.Errorf("expected a duplicate project name to conflict, got %v", err)
+	}
+
+	// Like the real API, the project list does not return the retention.
+	got, err := orgClient.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting project: %v", err)
+	}
+	if got.RetentionDays != 0 || got.Metadata["env"] != "dev" {
+		t.Errorf("unexpected listed project: %+v", got)
+	}
+
+	note := "ci"
+	projectKey, err := orgClient.CreateProjectApiKey(ctx, project.ID, &langfuse.CreateProjectApiKeyRequest{Note: &note})
+	if err != nil {
+		t.Fatalf("unexpected error creating project API key: %v", err)
+	}
+	if got, err := orgClient.GetProjectApiKey(ctx, project.ID, projectKey.ID); err != nil || got.Note == nil || *got.Note != note {
+		t.Errorf("GetProjectApiKey() = %+v, %v", got, err)
+	}
+
+	// Organization memberships are created through SCIM, then given a role.
+	user, err := orgClient.CreateSCIMUser(ctx, &langfuse.SCIMUserRequest{UserName: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error creating SCIM user: %v", err)
+	}
+	if _, err := orgClient.CreateSCIMUser(ctx, &langfuse.SCIMUserRequest{UserName: "jane@example.com"}); !langfuse.IsConflict(err) {
+		t.Errorf("expected a second SCIM user with the same email to conflict, got %v", err)
+	}
+	membership, err := orgClient.UpdateMembership(ctx, user.ID, &langfuse.UpdateMembershipRequest{Role: "MEMBER"})
+	if err != nil {
+		t.Fatalf("unexpected error updating membership: %v", err)
+	}
+	if membership.Role != "MEMBER" || membership.Email != "jane@example.com" || membership.ID != user.ID {
+		t.Errorf("unexpected membership: %+v", membership)
+	}
+
+	projectMembership, err := orgClient.CreateOrUpdateProjectMembership(ctx, project.ID, &langfuse.CreateProjectMembershipRequest{UserID: user.ID, Role: "VIEWER"})
+	if err != nil {
+		t.Fatalf("unexpected error creating project membership: %v", err)
+	}
+	if projectMembership.Role != "VIEWER" {
+		t.Errorf("unexpected project membership: %+v", projectMembership)
+	}
+	if _, err := orgClient.CreateOrUpdateProjectMembership(ctx, project.ID, &langfuse.CreateProjectMembershipRequest{UserID: "stranger", Role: "VIEWER"}); !langfuse.IsNotFound(err) {
+		t.Errorf("expected a project membership for a non-member to fail, got %v", err)
+	}
+
+	// Removing the member answers success false with a "deleted" message, which the client accepts.
+	if err := orgClient.RemoveMember(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error removing member: %v", err)
+	}
+	if _, err := orgClient.GetProjectMembership(ctx, project.ID, user.ID); !langfuse.IsNotFound(err) {
+		t.Errorf("expected the project membership to go with the organization membership, got %v", err)
+	}
+
+	if err := orgClient.DeleteProject(ctx, project.ID); err != nil {
+		t.Fatalf("unexpected error deleting project: %v", err)
+	}
+	if server.Exists(project.ID) || server.Exists(projectKey.ID) {
+		t.Errorf("expected the project and its keys to be deleted")
+	}
+}
+
+func TestServerLlmConnectionsWorkflow(t *testing.T) {
+	t.Parallel()
+
+	server := langfusetest.NewServer(t)
+	factory := langfuse.NewClientFactory(server.URL, server.AdminKey)
+	ctx := context.Background()
+	orgClient := newOrganizationClient(t, factory)
+
+	project, err := orgClient.CreateProject(ctx, &langfuse.CreateProjectRequest{Name: "web"})
+	if err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	projectKey, err := orgClient.CreateProjectApiKey(ctx, project.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating project API key: %v", err)
+	}
+	client := factory.NewLlmConnectionsClient("", projectKey.PublicKey, projectKey.SecretKey)
+
+	if _, err := langfuse.Collect(factory.NewLlmConnectionsClient("", "pk-unknown", "sk-unknown").ListLlmConnections(ctx)); !langfuse.IsUnauthorized(err) {
+		t.Errorf("expected unknown credentials to be rejected, got %v", err)
+	}
+
+	for _, provider := range []string{"openai", "anthropic", "azure"} {
+		if _, err := client.UpsertLlmConnection(ctx, &langfuse.UpsertLlmConnectionRequest{Adapter: provider, Provider: provider, SecretKey: "sk-" + provider + "-secret"}); err != nil {
+			t.Fatalf("unexpected error creating %s connection: %v", provider, err)
+		}
+	}
+	withDefaultModels := false
+	updated, err := client.UpsertLlmConnection(ctx, &langfuse.UpsertLlmConnectionRequest{
+		Adapter:           "openai",
+		Provider:          "openai",
+		SecretKey:         "sk-rotated-1234",
+		ExtraHeaders:      map[string]string{"X-Org": "acme"},
+		WithDefaultModels: &withDefaultModels,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error updating connection: %v", err)
+	}
+	if updated.DisplaySecretKey != "...1234" || updated.WithDefaultModels || len(updated.ExtraHeaderKeys) != 1 {
+		t.Errorf("unexpected updated connection: %+v", updated)
+	}
+
+	first, err := client.ListLlmConnectionsPage(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error listing connections: %v", err)
+	}
+	if len(first.Items) != 2 || first.Meta.TotalItems != 3 || first.Meta.TotalPages != 2 {
+		t.Errorf("unexpected first page: %+v", first)
+	}
+	connections, err := langfuse.Collect(client.ListLlmConnections(ctx))
+	if err != nil || len(connections) != 3 {
+		t.Fatalf("ListLlmConnections() = %+v, %v", connections, err)
+	}
+
+	if err := client.DeleteLlmConnection(ctx, updated.ID); err != nil {
+		t.Fatalf("unexpected error deleting connection: %v", err)
+	}
+	if err := client.DeleteLlmConnection(ctx, updated.ID); err != nil {
+		t.Errorf("expected deleting a missing connection to succeed, got %v", err)
+	}
+	if server.Exists(updated.ID) {
+		t.Errorf("expected the connection to be deleted")
+	}
+}
+
+// newOrganizationClient creates an organization through the admin API and returns a client for it.
+func newOrganizationClient(t *testing.T, factory langfuse.ClientFactory) langfuse.OrganizationClient {
+	t.Helper()
+
+	ctx := context.Background()
+	admin := factory.NewAdminClient()
+	org, err := admin.CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{Name: "acme"})
+	if err != nil {
+		t.Fatalf("unexpected error creating organization: %v", err)
+	}
+	key, err := admin.CreateOrganizationApiKey(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("unexpected error creating organization API key: %v", err)
+	}
+
+	return factory.NewOrganizationClient("", key.PublicKey, key.SecretKey)
+}
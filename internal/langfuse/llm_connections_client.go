@@ -3,8 +3,8 @@ package langfuse
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
-	"net/url"
 )
 
 //go:generate mockgen -destination=./mocks/mock_llm_connections_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse LlmConnectionsClient
@@ -34,98 +34,53 @@ type UpsertLlmConnectionRequest struct {
 	WithDefaultModels *bool             `json:"withDefaultModels,omitempty"`
 }
 
-type ListLlmConnectionsResponse struct {
+type listLlmConnectionsResponse struct {
 	Data []LlmConnection `json:"data"`
 	Meta PaginationMeta  `json:"meta"`
 }
 
-type PaginationMeta struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	TotalItems int `json:"totalItems"`
-	TotalPages int `json:"totalPages"`
-}
-
 type deleteLlmConnectionResponse struct {
 	Message string `json:"message"`
 }
 
+// ListLlmConnections iterates over every page of the list endpoint, while ListLlmConnectionsPage fetches a single one.
 type LlmConnectionsClient interface {
-	ListLlmConnections(ctx context.Context, page, limit *int) (*ListLlmConnectionsResponse, error)
+	ListLlmConnections(ctx context.Context) iter.Seq2[LlmConnection, error]
+	ListLlmConnectionsPage(ctx context.Context, page, limit int) (*Page[LlmConnection], error)
 	UpsertLlmConnection(ctx context.Context, req *UpsertLlmConnectionRequest) (*LlmConnection, error)
 	DeleteLlmConnection(ctx context.Context, id string) error
 }
 
 type llmConnectionsClientImpl struct {
-	host       string
-	publicKey  string
-	privateKey string
-	httpClient *http.Client
+	*apiClient
 }
 
 func NewLlmConnectionsClient(host, publicKey, privateKey string) LlmConnectionsClient {
-	return &llmConnectionsClientImpl{
-		host:       host,
-		publicKey:  publicKey,
-		privateKey: privateKey,
-		httpClient: &http.Client{},
-	}
+	return newLlmConnectionsClient(newAPIClient(host, basicAuth{publicKey: publicKey, secretKey: privateKey}, newDefaultHTTPClient()))
 }
 
-func (c *llmConnectionsClientImpl) makeRequest(ctx context.Context, methodType, apiPath string, body any) (*http.Response, error) {
-	req, err := buildBaseRequest(ctx, methodType, buildURL(c.host, apiPath), body)
-	if err != nil {
-		return nil, err
-	}
-	req.SetBasicAuth(c.publicKey, c.privateKey)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	return resp, nil
+func newLlmConnectionsClient(client *apiClient) LlmConnectionsClient {
+	return &llmConnectionsClientImpl{apiClient: client}
 }
 
-func (c *llmConnectionsClientImpl) ListLlmConnections(ctx context.Context, page, limit *int) (*ListLlmConnectionsResponse, error) {
-	baseURL := buildURL(c.host, "api/public/llm-connections")
-
-	if page != nil || limit != nil {
-		parsed, err := url.Parse(baseURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse llm connections URL: %w", err)
-		}
-		q := url.Values{}
-		if page != nil {
-			q.Set("page", fmt.Sprintf("%d", *page))
-		}
-		if limit != nil {
-			q.Set("limit", fmt.Sprintf("%d", *limit))
-		}
-		parsed.RawQuery = q.Encode()
-		baseURL = parsed.String()
-	}
-
-	req, err := buildBaseRequest(ctx, http.MethodGet, baseURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build list llm connections request: %w", err)
-	}
-	req.SetBasicAuth(c.publicKey, c.privateKey)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make list llm connections request: %w", err)
-	}
+func (c *llmConnectionsClientImpl) ListLlmConnections(ctx context.Context) iter.Seq2[LlmConnection, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[LlmConnection], error) {
+		return c.ListLlmConnectionsPage(ctx, page, DefaultPageSize)
+	})
+}
 
-	var listResp ListLlmConnectionsResponse
-	if err := decodeResponse(resp, &listResp); err != nil {
+func (c *llmConnectionsClientImpl) ListLlmConnectionsPage(ctx context.Context, page, limit int) (*Page[LlmConnection], error) {
+	var listResp listLlmConnectionsResponse
+	if err := c.getJSON(ctx, collectionLlmConnections, pagePath("api/public/llm-connections", page, limit), &listResp); err != nil {
 		return nil, err
 	}
 
-	return &listResp, nil
+	return &Page[LlmConnection]{Items: listResp.Data, Meta: listResp.Meta}, nil
 }
 
 func (c *llmConnectionsClientImpl) UpsertLlmConnection(ctx context.Context, req *UpsertLlmConnectionRequest) (*LlmConnection, error) {
+	defer c.invalidate(collectionLlmConnections)
+
 	resp, err := c.makeRequest(ctx, http.MethodPut, "api/public/llm-connections", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make upsert llm connection request: %w", err)
@@ -140,18 +95,19 @@ func (c *llmConnectionsClientImpl) UpsertLlmConnection(ctx context.Context, req
 }
 
 func (c *llmConnectionsClientImpl) DeleteLlmConnection(ctx context.Context, id string) error {
+	defer c.invalidate(collectionLlmConnections)
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/public/llm-connections/%s", id), nil)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		_ = resp.Body.Close()
-		return nil
-	}
-
 	var deleteResp deleteLlmConnectionResponse
 	if err := decodeResponse(resp, &deleteResp); err != nil {
+		// The connection is already gone, which is the desired outcome.
+		if IsNotFound(err) {
+			return nil
+		}
 		return err
 	}
 
@@ -0,0 +1,173 @@
+package langfuse
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// HTTPLogSubsystem is the tflog subsystem used for request tracing. Its level can be set independently
+	// from the rest of the provider with TF_LOG_PROVIDER_LANGFUSE_HTTP.
+	HTTPLogSubsystem = "langfuse.http"
+
+	redactedValue    = "***"
+	maxLoggedBodyLen = 64 * 1024
+)
+
+// sensitiveBodyKeys are JSON keys whose values are masked in logged bodies, compared case-insensitively.
+var sensitiveBodyKeys = map[string]bool{
+	"secretkey":   true,
+	"secret_key":  true,
+	"password":    true,
+	"privatekey":  true,
+	"private_key": true,
+}
+
+// sensitiveMapKeys are JSON keys holding objects whose values are all masked, keeping only their keys.
+var sensitiveMapKeys = map[string]bool{
+	"extraheaders":  true,
+	"extra_headers": true,
+}
+
+// loggingTransport logs every attempt of a request: method, path, status, latency and a request ID at DEBUG,
+// headers and bodies at TRACE. Credentials are masked before anything is logged.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{next: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), HTTPLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_LANGFUSE", "HTTP"))
+	ctx = tflog.SubsystemSetField(ctx, HTTPLogSubsystem, "request_id", newRequestID())
+	ctx = tflog.SubsystemSetField(ctx, HTTPLogSubsystem, "method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, HTTPLogSubsystem, "path", req.URL.Path)
+
+	tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "Sending request to Langfuse")
+	tflog.SubsystemTrace(ctx, HTTPLogSubsystem, "Request details", map[string]any{
+		"query":   req.URL.RawQuery,
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(peekRequestBody(req)),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "Request to Langfuse failed", map[string]any{
+			"duration_ms": duration.Milliseconds(),
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "Received response from Langfuse", map[string]any{
+		"status_code": resp.StatusCode,
+		"duration_ms": duration.Milliseconds(),
+	})
+
+	body, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	tflog.SubsystemTrace(ctx, HTTPLogSubsystem, "Response details", map[string]any{
+		"status_code": resp.StatusCode,
+		"headers":     redactHeaders(resp.Header),
+		"body":        redactBody(body),
+	})
+
+	return resp, nil
+}
+
+// peekRequestBody returns a copy of the request body without consuming it.
+func peekRequestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = body.Close() }()
+
+	content, _ := io.ReadAll(body)
+	return content
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, values := range headers {
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+			redacted[name] = redactedValue
+		default:
+			redacted[name] = strings.Join(values, ", ")
+		}
+	}
+	return redacted
+}
+
+// redactBody masks credentials in a JSON body. Bodies that are not JSON are logged as they are, truncated.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	return truncate(string(scrubBody(body)))
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, inner := range v {
+			normalized := strings.ToLower(key)
+			switch {
+			case sensitiveBodyKeys[normalized]:
+				v[key] = redactedValue
+			case sensitiveMapKeys[normalized]:
+				if headers, ok := inner.(map[string]any); ok {
+					for headerName := range headers {
+						headers[headerName] = redactedValue
+					}
+				} else {
+					v[key] = redactedValue
+				}
+			default:
+				v[key] = redactValue(inner)
+			}
+		}
+		return v
+	case []any:
+		for i, inner := range v {
+			v[i] = redactValue(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func truncate(s string) string {
+	if len(s) <= maxLoggedBodyLen {
+		return s
+	}
+	return s[:maxLoggedBodyLen] + "...(truncated)"
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,71 @@
+package langfuse
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBodyMasksCredentials(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"provider":"openai","secretKey":"sk-live","extraHeaders":{"X-Api-Key":"header-secret"},` +
+		`"apiKeys":[{"id":"1","secret_key":"sk-nested"}],"password":"hunter2"}`)
+
+	redacted := redactBody(body)
+
+	for _, secret := range []string{"sk-live", "header-secret", "sk-nested", "hunter2"} {
+		if strings.Contains(redacted, secret) {
+			t.Fatalf("redacted body still contains %q: %s", secret, redacted)
+		}
+	}
+	for _, kept := range []string{`"provider":"openai"`, `"X-Api-Key":"***"`, `"id":"1"`} {
+		if !strings.Contains(redacted, kept) {
+			t.Fatalf("redacted body is missing %q: %s", kept, redacted)
+		}
+	}
+}
+
+func TestLoggingTransportLogsRedactedRequests(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_LANGFUSE_HTTP", "TRACE")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"key-1","publicKey":"pk-lf-1","secretKey":"sk-lf-response"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := newAPIClient(server.URL, basicAuth{publicKey: "pk-org", secretKey: "sk-org"}, &http.Client{
+		Transport: newLoggingTransport(http.DefaultTransport),
+	})
+	resp, err := client.makeRequest(ctx, http.MethodPost, "api/public/projects/proj-1/apiKeys", map[string]string{"note": "ci"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var apiKey ProjectApiKey
+	if err := decodeResponse(resp, &apiKey); err != nil {
+		t.Fatalf("response body must still be readable after logging: %v", err)
+	}
+	if apiKey.SecretKey != "sk-lf-response" {
+		t.Fatalf("unexpected secret key decoded: %q", apiKey.SecretKey)
+	}
+
+	logs := output.String()
+	for _, expected := range []string{`"@module":"provider.langfuse.http"`, `"status_code":200`, `"request_id"`, `"path":"/api/public/projects/proj-1/apiKeys"`, `\"note\":\"ci\"`} {
+		if !strings.Contains(logs, expected) {
+			t.Fatalf("expected logs to contain %s, got:\n%s", expected, logs)
+		}
+	}
+	for _, secret := range []string{"sk-org", "sk-lf-response"} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("logs leaked %q:\n%s", secret, logs)
+		}
+	}
+}
@@ -1,21 +1,28 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: github.com/langfuse/terraform-provider-langfuse/internal/langfuse (interfaces: AdminClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_admin_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse AdminClient
+//
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
 	langfuse "github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockAdminClient is a mock of AdminClient interface.
 type MockAdminClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockAdminClientMockRecorder
+	isgomock struct{}
 }
 
 // MockAdminClientMockRecorder is the mock recorder for MockAdminClient.
@@ -36,119 +43,162 @@ func (m *MockAdminClient) EXPECT() *MockAdminClientMockRecorder {
 }
 
 // CreateOrganization mocks base method.
-func (m *MockAdminClient) CreateOrganization(arg0 context.Context, arg1 *langfuse.CreateOrganizationRequest) (*langfuse.Organization, error) {
+func (m *MockAdminClient) CreateOrganization(ctx context.Context, request *langfuse.CreateOrganizationRequest) (*langfuse.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, request)
 	ret0, _ := ret[0].(*langfuse.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrganization indicates an expected call of CreateOrganization.
-func (mr *MockAdminClientMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) CreateOrganization(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockAdminClient)(nil).CreateOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockAdminClient)(nil).CreateOrganization), ctx, request)
 }
 
 // CreateOrganizationApiKey mocks base method.
-func (m *MockAdminClient) CreateOrganizationApiKey(arg0 context.Context, arg1 string) (*langfuse.OrganizationApiKey, error) {
+func (m *MockAdminClient) CreateOrganizationApiKey(ctx context.Context, orgID string) (*langfuse.OrganizationApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrganizationApiKey", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateOrganizationApiKey", ctx, orgID)
 	ret0, _ := ret[0].(*langfuse.OrganizationApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrganizationApiKey indicates an expected call of CreateOrganizationApiKey.
-func (mr *MockAdminClientMockRecorder) CreateOrganizationApiKey(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) CreateOrganizationApiKey(ctx, orgID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).CreateOrganizationApiKey), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).CreateOrganizationApiKey), ctx, orgID)
 }
 
 // DeleteOrganization mocks base method.
-func (m *MockAdminClient) DeleteOrganization(arg0 context.Context, arg1 string) error {
+func (m *MockAdminClient) DeleteOrganization(ctx context.Context, orgID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, orgID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOrganization indicates an expected call of DeleteOrganization.
-func (mr *MockAdminClientMockRecorder) DeleteOrganization(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) DeleteOrganization(ctx, orgID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockAdminClient)(nil).DeleteOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockAdminClient)(nil).DeleteOrganization), ctx, orgID)
 }
 
 // DeleteOrganizationApiKey mocks base method.
-func (m *MockAdminClient) DeleteOrganizationApiKey(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockAdminClient) DeleteOrganizationApiKey(ctx context.Context, orgID, apiKeyID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrganizationApiKey", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteOrganizationApiKey", ctx, orgID, apiKeyID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOrganizationApiKey indicates an expected call of DeleteOrganizationApiKey.
-func (mr *MockAdminClientMockRecorder) DeleteOrganizationApiKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) DeleteOrganizationApiKey(ctx, orgID, apiKeyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).DeleteOrganizationApiKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).DeleteOrganizationApiKey), ctx, orgID, apiKeyID)
 }
 
 // GetOrganization mocks base method.
-func (m *MockAdminClient) GetOrganization(arg0 context.Context, arg1 string) (*langfuse.Organization, error) {
+func (m *MockAdminClient) GetOrganization(ctx context.Context, orgID string) (*langfuse.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetOrganization", ctx, orgID)
 	ret0, _ := ret[0].(*langfuse.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrganization indicates an expected call of GetOrganization.
-func (mr *MockAdminClientMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) GetOrganization(ctx, orgID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockAdminClient)(nil).GetOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockAdminClient)(nil).GetOrganization), ctx, orgID)
 }
 
 // GetOrganizationApiKey mocks base method.
-func (m *MockAdminClient) GetOrganizationApiKey(arg0 context.Context, arg1, arg2 string) (*langfuse.OrganizationApiKey, error) {
+func (m *MockAdminClient) GetOrganizationApiKey(ctx context.Context, orgID, apiKeyID string) (*langfuse.OrganizationApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrganizationApiKey", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetOrganizationApiKey", ctx, orgID, apiKeyID)
 	ret0, _ := ret[0].(*langfuse.OrganizationApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrganizationApiKey indicates an expected call of GetOrganizationApiKey.
-func (mr *MockAdminClientMockRecorder) GetOrganizationApiKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) GetOrganizationApiKey(ctx, orgID, apiKeyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).GetOrganizationApiKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationApiKey", reflect.TypeOf((*MockAdminClient)(nil).GetOrganizationApiKey), ctx, orgID, apiKeyID)
 }
 
-// ListOrganizations mocks base method.
-func (m *MockAdminClient) ListOrganizations(arg0 context.Context) ([]*langfuse.Organization, error) {
+// ListOrganizationApiKeys mocks base method.
+func (m *MockAdminClient) ListOrganizationApiKeys(ctx context.Context, orgID string) iter.Seq2[langfuse.OrganizationApiKey, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationApiKeys", ctx, orgID)
+	ret0, _ := ret[0].(iter.Seq2[langfuse.OrganizationApiKey, error])
+	return ret0
+}
+
+// ListOrganizationApiKeys indicates an expected call of ListOrganizationApiKeys.
+func (mr *MockAdminClientMockRecorder) ListOrganizationApiKeys(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationApiKeys", reflect.TypeOf((*MockAdminClient)(nil).ListOrganizationApiKeys), ctx, orgID)
+}
+
+// ListOrganizationApiKeysPage mocks base method.
+func (m *MockAdminClient) ListOrganizationApiKeysPage(ctx context.Context, orgID string, page, limit int) (*langfuse.Page[langfuse.OrganizationApiKey], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrganizations", arg0)
-	ret0, _ := ret[0].([]*langfuse.Organization)
+	ret := m.ctrl.Call(m, "ListOrganizationApiKeysPage", ctx, orgID, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[langfuse.OrganizationApiKey])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListOrganizationApiKeysPage indicates an expected call of ListOrganizationApiKeysPage.
+func (mr *MockAdminClientMockRecorder) ListOrganizationApiKeysPage(ctx, orgID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationApiKeysPage", reflect.TypeOf((*MockAdminClient)(nil).ListOrganizationApiKeysPage), ctx, orgID, page, limit)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockAdminClient) ListOrganizations(ctx context.Context) iter.Seq2[*langfuse.Organization, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx)
+	ret0, _ := ret[0].(iter.Seq2[*langfuse.Organization, error])
+	return ret0
+}
+
 // ListOrganizations indicates an expected call of ListOrganizations.
-func (mr *MockAdminClientMockRecorder) ListOrganizations(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) ListOrganizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockAdminClient)(nil).ListOrganizations), ctx)
+}
+
+// ListOrganizationsPage mocks base method.
+func (m *MockAdminClient) ListOrganizationsPage(ctx context.Context, page, limit int) (*langfuse.Page[*langfuse.Organization], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationsPage", ctx, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[*langfuse.Organization])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationsPage indicates an expected call of ListOrganizationsPage.
+func (mr *MockAdminClientMockRecorder) ListOrganizationsPage(ctx, page, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockAdminClient)(nil).ListOrganizations), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationsPage", reflect.TypeOf((*MockAdminClient)(nil).ListOrganizationsPage), ctx, page, limit)
 }
 
 // UpdateOrganization mocks base method.
-func (m *MockAdminClient) UpdateOrganization(arg0 context.Context, arg1 string, arg2 *langfuse.UpdateOrganizationRequest) (*langfuse.Organization, error) {
+func (m *MockAdminClient) UpdateOrganization(ctx context.Context, orgID string, request *langfuse.UpdateOrganizationRequest) (*langfuse.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrganization", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateOrganization", ctx, orgID, request)
 	ret0, _ := ret[0].(*langfuse.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOrganization indicates an expected call of UpdateOrganization.
-func (mr *MockAdminClientMockRecorder) UpdateOrganization(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAdminClientMockRecorder) UpdateOrganization(ctx, orgID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockAdminClient)(nil).UpdateOrganization), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockAdminClient)(nil).UpdateOrganization), ctx, orgID, request)
 }
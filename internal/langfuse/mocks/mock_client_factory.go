@@ -1,14 +1,38 @@
 package mocks
 
 import (
-	gomock "github.com/golang/mock/gomock"
+	"context"
+
 	langfuse "github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	gomock "go.uber.org/mock/gomock"
 )
 
 type mockClientFactory struct {
 	AdminClient          *MockAdminClient
 	OrganizationClient   *MockOrganizationClient
 	LlmConnectionsClient *MockLlmConnectionsClient
+
+	// OrganizationCredentials and ProjectCredentials are reported as the factory's default credentials,
+	// CredentialSets as its named credential sets, Metadata as its default metadata and DeletionProtection as
+	// its default deletion protection. MissingAdminAPIKey makes the factory report that it has no admin API key, and UnknownAdminAPIKey that its
+	// admin API key is not known yet.
+	OrganizationCredentials langfuse.Credentials
+	ProjectCredentials      langfuse.Credentials
+	CredentialSets          map[string]langfuse.CredentialSet
+	Metadata                map[string]string
+	DeletionProtection      bool
+	MissingAdminAPIKey      bool
+	UnknownAdminAPIKey      bool
+
+	// OrganizationClientCredentials records the arguments of the last call to NewOrganizationClient.
+	OrganizationClientCredentials ClientCredentials
+}
+
+// ClientCredentials are the credential set and keys a client was created with.
+type ClientCredentials struct {
+	CredentialSet string
+	PublicKey     string
+	PrivateKey    string
 }
 
 func NewMockClientFactory(ctrl *gomock.Controller) *mockClientFactory {
@@ -23,10 +47,49 @@ func (cf *mockClientFactory) NewAdminClient() langfuse.AdminClient {
 	return cf.AdminClient
 }
 
-func (cf *mockClientFactory) NewOrganizationClient(publicKey, privateKey string) langfuse.OrganizationClient {
+func (cf *mockClientFactory) HasAdminAPIKey() bool {
+	return !cf.MissingAdminAPIKey
+}
+
+func (cf *mockClientFactory) AdminAPIKeyUnknown() bool {
+	return cf.UnknownAdminAPIKey
+}
+
+func (cf *mockClientFactory) NewOrganizationClient(credentialSet, publicKey, privateKey string) langfuse.OrganizationClient {
+	cf.OrganizationClientCredentials = ClientCredentials{CredentialSet: credentialSet, PublicKey: publicKey, PrivateKey: privateKey}
 	return cf.OrganizationClient
 }
 
-func (cf *mockClientFactory) NewLlmConnectionsClient(publicKey, privateKey string) langfuse.LlmConnectionsClient {
+func (cf *mockClientFactory) NewLlmConnectionsClient(credentialSet, publicKey, privateKey string) langfuse.LlmConnectionsClient {
 	return cf.LlmConnectionsClient
 }
+
+func (cf *mockClientFactory) DefaultOrganizationCredentials() langfuse.Credentials {
+	return cf.OrganizationCredentials
+}
+
+func (cf *mockClientFactory) DefaultProjectCredentials() langfuse.Credentials {
+	return cf.ProjectCredentials
+}
+
+func (cf *mockClientFactory) CredentialSet(name string) (langfuse.CredentialSet, bool) {
+	set, ok := cf.CredentialSets[name]
+	return set, ok
+}
+
+func (cf *mockClientFactory) DefaultMetadata() map[string]string {
+	return cf.Metadata
+}
+
+func (cf *mockClientFactory) DefaultDeletionProtection() bool {
+	return cf.DeletionProtection
+}
+
+func (cf *mockClientFactory) Health(ctx context.Context) (*langfuse.HealthStatus, error) {
+	return &langfuse.HealthStatus{Status: "OK"}, nil
+}
+
+// ServerInfo reports a self-hosted instance, which satisfies every requirement.
+func (cf *mockClientFactory) ServerInfo() *langfuse.ServerInfo {
+	return &langfuse.ServerInfo{}
+}
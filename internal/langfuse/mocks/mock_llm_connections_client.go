@@ -1,21 +1,28 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: github.com/langfuse/terraform-provider-langfuse/internal/langfuse (interfaces: LlmConnectionsClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_llm_connections_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse LlmConnectionsClient
+//
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
 	langfuse "github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockLlmConnectionsClient is a mock of LlmConnectionsClient interface.
 type MockLlmConnectionsClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockLlmConnectionsClientMockRecorder
+	isgomock struct{}
 }
 
 // MockLlmConnectionsClientMockRecorder is the mock recorder for MockLlmConnectionsClient.
@@ -36,45 +43,59 @@ func (m *MockLlmConnectionsClient) EXPECT() *MockLlmConnectionsClientMockRecorde
 }
 
 // DeleteLlmConnection mocks base method.
-func (m *MockLlmConnectionsClient) DeleteLlmConnection(arg0 context.Context, arg1 string) error {
+func (m *MockLlmConnectionsClient) DeleteLlmConnection(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLlmConnection", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteLlmConnection", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLlmConnection indicates an expected call of DeleteLlmConnection.
-func (mr *MockLlmConnectionsClientMockRecorder) DeleteLlmConnection(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockLlmConnectionsClientMockRecorder) DeleteLlmConnection(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLlmConnection", reflect.TypeOf((*MockLlmConnectionsClient)(nil).DeleteLlmConnection), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLlmConnection", reflect.TypeOf((*MockLlmConnectionsClient)(nil).DeleteLlmConnection), ctx, id)
 }
 
 // ListLlmConnections mocks base method.
-func (m *MockLlmConnectionsClient) ListLlmConnections(arg0 context.Context, arg1, arg2 *int) (*langfuse.ListLlmConnectionsResponse, error) {
+func (m *MockLlmConnectionsClient) ListLlmConnections(ctx context.Context) iter.Seq2[langfuse.LlmConnection, error] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLlmConnections", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*langfuse.ListLlmConnectionsResponse)
+	ret := m.ctrl.Call(m, "ListLlmConnections", ctx)
+	ret0, _ := ret[0].(iter.Seq2[langfuse.LlmConnection, error])
+	return ret0
+}
+
+// ListLlmConnections indicates an expected call of ListLlmConnections.
+func (mr *MockLlmConnectionsClientMockRecorder) ListLlmConnections(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLlmConnections", reflect.TypeOf((*MockLlmConnectionsClient)(nil).ListLlmConnections), ctx)
+}
+
+// ListLlmConnectionsPage mocks base method.
+func (m *MockLlmConnectionsClient) ListLlmConnectionsPage(ctx context.Context, page, limit int) (*langfuse.Page[langfuse.LlmConnection], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLlmConnectionsPage", ctx, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[langfuse.LlmConnection])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLlmConnections indicates an expected call of ListLlmConnections.
-func (mr *MockLlmConnectionsClientMockRecorder) ListLlmConnections(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ListLlmConnectionsPage indicates an expected call of ListLlmConnectionsPage.
+func (mr *MockLlmConnectionsClientMockRecorder) ListLlmConnectionsPage(ctx, page, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLlmConnections", reflect.TypeOf((*MockLlmConnectionsClient)(nil).ListLlmConnections), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLlmConnectionsPage", reflect.TypeOf((*MockLlmConnectionsClient)(nil).ListLlmConnectionsPage), ctx, page, limit)
 }
 
 // UpsertLlmConnection mocks base method.
-func (m *MockLlmConnectionsClient) UpsertLlmConnection(arg0 context.Context, arg1 *langfuse.UpsertLlmConnectionRequest) (*langfuse.LlmConnection, error) {
+func (m *MockLlmConnectionsClient) UpsertLlmConnection(ctx context.Context, req *langfuse.UpsertLlmConnectionRequest) (*langfuse.LlmConnection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertLlmConnection", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpsertLlmConnection", ctx, req)
 	ret0, _ := ret[0].(*langfuse.LlmConnection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertLlmConnection indicates an expected call of UpsertLlmConnection.
-func (mr *MockLlmConnectionsClientMockRecorder) UpsertLlmConnection(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockLlmConnectionsClientMockRecorder) UpsertLlmConnection(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLlmConnection", reflect.TypeOf((*MockLlmConnectionsClient)(nil).UpsertLlmConnection), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLlmConnection", reflect.TypeOf((*MockLlmConnectionsClient)(nil).UpsertLlmConnection), ctx, req)
 }
@@ -1,21 +1,28 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: github.com/langfuse/terraform-provider-langfuse/internal/langfuse (interfaces: OrganizationClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock_organization_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse OrganizationClient
+//
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
 	langfuse "github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockOrganizationClient is a mock of OrganizationClient interface.
 type MockOrganizationClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockOrganizationClientMockRecorder
+	isgomock struct{}
 }
 
 // MockOrganizationClientMockRecorder is the mock recorder for MockOrganizationClient.
@@ -36,252 +43,323 @@ func (m *MockOrganizationClient) EXPECT() *MockOrganizationClientMockRecorder {
 }
 
 // CreateOrUpdateProjectMembership mocks base method.
-func (m *MockOrganizationClient) CreateOrUpdateProjectMembership(arg0 context.Context, arg1 string, arg2 *langfuse.CreateProjectMembershipRequest) (*langfuse.ProjectMembership, error) {
+func (m *MockOrganizationClient) CreateOrUpdateProjectMembership(ctx context.Context, projectID string, request *langfuse.CreateProjectMembershipRequest) (*langfuse.ProjectMembership, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateProjectMembership", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateOrUpdateProjectMembership", ctx, projectID, request)
 	ret0, _ := ret[0].(*langfuse.ProjectMembership)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrUpdateProjectMembership indicates an expected call of CreateOrUpdateProjectMembership.
-func (mr *MockOrganizationClientMockRecorder) CreateOrUpdateProjectMembership(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) CreateOrUpdateProjectMembership(ctx, projectID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).CreateOrUpdateProjectMembership), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).CreateOrUpdateProjectMembership), ctx, projectID, request)
 }
 
 // CreateProject mocks base method.
-func (m *MockOrganizationClient) CreateProject(arg0 context.Context, arg1 *langfuse.CreateProjectRequest) (*langfuse.Project, error) {
+func (m *MockOrganizationClient) CreateProject(ctx context.Context, request *langfuse.CreateProjectRequest) (*langfuse.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateProject", ctx, request)
 	ret0, _ := ret[0].(*langfuse.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProject indicates an expected call of CreateProject.
-func (mr *MockOrganizationClientMockRecorder) CreateProject(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) CreateProject(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockOrganizationClient)(nil).CreateProject), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockOrganizationClient)(nil).CreateProject), ctx, request)
 }
 
 // CreateProjectApiKey mocks base method.
-func (m *MockOrganizationClient) CreateProjectApiKey(arg0 context.Context, arg1 string, arg2 *langfuse.CreateProjectApiKeyRequest) (*langfuse.ProjectApiKey, error) {
+func (m *MockOrganizationClient) CreateProjectApiKey(ctx context.Context, projectID string, request *langfuse.CreateProjectApiKeyRequest) (*langfuse.ProjectApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProjectApiKey", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateProjectApiKey", ctx, projectID, request)
 	ret0, _ := ret[0].(*langfuse.ProjectApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProjectApiKey indicates an expected call of CreateProjectApiKey.
-func (mr *MockOrganizationClientMockRecorder) CreateProjectApiKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) CreateProjectApiKey(ctx, projectID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).CreateProjectApiKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).CreateProjectApiKey), ctx, projectID, request)
 }
 
 // CreateSCIMUser mocks base method.
-func (m *MockOrganizationClient) CreateSCIMUser(arg0 context.Context, arg1 *langfuse.SCIMUserRequest) (*langfuse.SCIMUserResponse, error) {
+func (m *MockOrganizationClient) CreateSCIMUser(ctx context.Context, request *langfuse.SCIMUserRequest) (*langfuse.SCIMUserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSCIMUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateSCIMUser", ctx, request)
 	ret0, _ := ret[0].(*langfuse.SCIMUserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSCIMUser indicates an expected call of CreateSCIMUser.
-func (mr *MockOrganizationClientMockRecorder) CreateSCIMUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) CreateSCIMUser(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMUser", reflect.TypeOf((*MockOrganizationClient)(nil).CreateSCIMUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMUser", reflect.TypeOf((*MockOrganizationClient)(nil).CreateSCIMUser), ctx, request)
 }
 
 // DeleteProject mocks base method.
-func (m *MockOrganizationClient) DeleteProject(arg0 context.Context, arg1 string) error {
+func (m *MockOrganizationClient) DeleteProject(ctx context.Context, projectID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProject", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, projectID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProject indicates an expected call of DeleteProject.
-func (mr *MockOrganizationClientMockRecorder) DeleteProject(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) DeleteProject(ctx, projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProject), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProject), ctx, projectID)
 }
 
 // DeleteProjectApiKey mocks base method.
-func (m *MockOrganizationClient) DeleteProjectApiKey(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockOrganizationClient) DeleteProjectApiKey(ctx context.Context, projectID, apiKeyID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProjectApiKey", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteProjectApiKey", ctx, projectID, apiKeyID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProjectApiKey indicates an expected call of DeleteProjectApiKey.
-func (mr *MockOrganizationClientMockRecorder) DeleteProjectApiKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) DeleteProjectApiKey(ctx, projectID, apiKeyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProjectApiKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProjectApiKey), ctx, projectID, apiKeyID)
 }
 
 // DeleteProjectMembership mocks base method.
-func (m *MockOrganizationClient) DeleteProjectMembership(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockOrganizationClient) DeleteProjectMembership(ctx context.Context, projectID, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProjectMembership", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteProjectMembership", ctx, projectID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProjectMembership indicates an expected call of DeleteProjectMembership.
-func (mr *MockOrganizationClientMockRecorder) DeleteProjectMembership(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) DeleteProjectMembership(ctx, projectID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProjectMembership), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).DeleteProjectMembership), ctx, projectID, userID)
 }
 
 // GetMembership mocks base method.
-func (m *MockOrganizationClient) GetMembership(arg0 context.Context, arg1 string) (*langfuse.OrganizationMembership, error) {
+func (m *MockOrganizationClient) GetMembership(ctx context.Context, membershipID string) (*langfuse.OrganizationMembership, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembership", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetMembership", ctx, membershipID)
 	ret0, _ := ret[0].(*langfuse.OrganizationMembership)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMembership indicates an expected call of GetMembership.
-func (mr *MockOrganizationClientMockRecorder) GetMembership(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) GetMembership(ctx, membershipID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockOrganizationClient)(nil).GetMembership), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockOrganizationClient)(nil).GetMembership), ctx, membershipID)
 }
 
 // GetProject mocks base method.
-func (m *MockOrganizationClient) GetProject(arg0 context.Context, arg1 string) (*langfuse.Project, error) {
+func (m *MockOrganizationClient) GetProject(ctx context.Context, projectID string) (*langfuse.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProject", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetProject", ctx, projectID)
 	ret0, _ := ret[0].(*langfuse.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProject indicates an expected call of GetProject.
-func (mr *MockOrganizationClientMockRecorder) GetProject(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) GetProject(ctx, projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockOrganizationClient)(nil).GetProject), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockOrganizationClient)(nil).GetProject), ctx, projectID)
 }
 
 // GetProjectApiKey mocks base method.
-func (m *MockOrganizationClient) GetProjectApiKey(arg0 context.Context, arg1, arg2 string) (*langfuse.ProjectApiKey, error) {
+func (m *MockOrganizationClient) GetProjectApiKey(ctx context.Context, projectID, apiKeyID string) (*langfuse.ProjectApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectApiKey", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetProjectApiKey", ctx, projectID, apiKeyID)
 	ret0, _ := ret[0].(*langfuse.ProjectApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjectApiKey indicates an expected call of GetProjectApiKey.
-func (mr *MockOrganizationClientMockRecorder) GetProjectApiKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) GetProjectApiKey(ctx, projectID, apiKeyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).GetProjectApiKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectApiKey", reflect.TypeOf((*MockOrganizationClient)(nil).GetProjectApiKey), ctx, projectID, apiKeyID)
 }
 
 // GetProjectMembership mocks base method.
-func (m *MockOrganizationClient) GetProjectMembership(arg0 context.Context, arg1, arg2 string) (*langfuse.ProjectMembership, error) {
+func (m *MockOrganizationClient) GetProjectMembership(ctx context.Context, projectID, membershipID string) (*langfuse.ProjectMembership, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectMembership", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetProjectMembership", ctx, projectID, membershipID)
 	ret0, _ := ret[0].(*langfuse.ProjectMembership)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjectMembership indicates an expected call of GetProjectMembership.
-func (mr *MockOrganizationClientMockRecorder) GetProjectMembership(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) GetProjectMembership(ctx, projectID, membershipID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).GetProjectMembership), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMembership", reflect.TypeOf((*MockOrganizationClient)(nil).GetProjectMembership), ctx, projectID, membershipID)
 }
 
 // ListMemberships mocks base method.
-func (m *MockOrganizationClient) ListMemberships(arg0 context.Context) ([]langfuse.OrganizationMembership, error) {
+func (m *MockOrganizationClient) ListMemberships(ctx context.Context) iter.Seq2[langfuse.OrganizationMembership, error] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMemberships", arg0)
-	ret0, _ := ret[0].([]langfuse.OrganizationMembership)
+	ret := m.ctrl.Call(m, "ListMemberships", ctx)
+	ret0, _ := ret[0].(iter.Seq2[langfuse.OrganizationMembership, error])
+	return ret0
+}
+
+// ListMemberships indicates an expected call of ListMemberships.
+func (mr *MockOrganizationClientMockRecorder) ListMemberships(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberships", reflect.TypeOf((*MockOrganizationClient)(nil).ListMemberships), ctx)
+}
+
+// ListMembershipsPage mocks base method.
+func (m *MockOrganizationClient) ListMembershipsPage(ctx context.Context, page, limit int) (*langfuse.Page[langfuse.OrganizationMembership], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembershipsPage", ctx, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[langfuse.OrganizationMembership])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMemberships indicates an expected call of ListMemberships.
-func (mr *MockOrganizationClientMockRecorder) ListMemberships(arg0 interface{}) *gomock.Call {
+// ListMembershipsPage indicates an expected call of ListMembershipsPage.
+func (mr *MockOrganizationClientMockRecorder) ListMembershipsPage(ctx, page, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberships", reflect.TypeOf((*MockOrganizationClient)(nil).ListMemberships), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembershipsPage", reflect.TypeOf((*MockOrganizationClient)(nil).ListMembershipsPage), ctx, page, limit)
 }
 
-// ListProjectMemberships mocks base method.
-func (m *MockOrganizationClient) ListProjectMemberships(arg0 context.Context, arg1 string) ([]langfuse.ProjectMembership, error) {
+// ListProjectApiKeys mocks base method.
+func (m *MockOrganizationClient) ListProjectApiKeys(ctx context.Context, projectID string) iter.Seq2[langfuse.ProjectApiKey, error] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProjectMemberships", arg0, arg1)
-	ret0, _ := ret[0].([]langfuse.ProjectMembership)
+	ret := m.ctrl.Call(m, "ListProjectApiKeys", ctx, projectID)
+	ret0, _ := ret[0].(iter.Seq2[langfuse.ProjectApiKey, error])
+	return ret0
+}
+
+// ListProjectApiKeys indicates an expected call of ListProjectApiKeys.
+func (mr *MockOrganizationClientMockRecorder) ListProjectApiKeys(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectApiKeys", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectApiKeys), ctx, projectID)
+}
+
+// ListProjectApiKeysPage mocks base method.
+func (m *MockOrganizationClient) ListProjectApiKeysPage(ctx context.Context, projectID string, page, limit int) (*langfuse.Page[langfuse.ProjectApiKey], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectApiKeysPage", ctx, projectID, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[langfuse.ProjectApiKey])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListProjectApiKeysPage indicates an expected call of ListProjectApiKeysPage.
+func (mr *MockOrganizationClientMockRecorder) ListProjectApiKeysPage(ctx, projectID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectApiKeysPage", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectApiKeysPage), ctx, projectID, page, limit)
+}
+
+// ListProjectMemberships mocks base method.
+func (m *MockOrganizationClient) ListProjectMemberships(ctx context.Context, projectID string) iter.Seq2[langfuse.ProjectMembership, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectMemberships", ctx, projectID)
+	ret0, _ := ret[0].(iter.Seq2[langfuse.ProjectMembership, error])
+	return ret0
+}
+
 // ListProjectMemberships indicates an expected call of ListProjectMemberships.
-func (mr *MockOrganizationClientMockRecorder) ListProjectMemberships(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) ListProjectMemberships(ctx, projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectMemberships", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectMemberships), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectMemberships", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectMemberships), ctx, projectID)
 }
 
-// ListProjects mocks base method.
-func (m *MockOrganizationClient) ListProjects(arg0 context.Context) ([]*langfuse.Project, error) {
+// ListProjectMembershipsPage mocks base method.
+func (m *MockOrganizationClient) ListProjectMembershipsPage(ctx context.Context, projectID string, page, limit int) (*langfuse.Page[langfuse.ProjectMembership], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProjects", arg0)
-	ret0, _ := ret[0].([]*langfuse.Project)
+	ret := m.ctrl.Call(m, "ListProjectMembershipsPage", ctx, projectID, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[langfuse.ProjectMembership])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListProjectMembershipsPage indicates an expected call of ListProjectMembershipsPage.
+func (mr *MockOrganizationClientMockRecorder) ListProjectMembershipsPage(ctx, projectID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectMembershipsPage", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectMembershipsPage), ctx, projectID, page, limit)
+}
+
+// ListProjects mocks base method.
+func (m *MockOrganizationClient) ListProjects(ctx context.Context) iter.Seq2[*langfuse.Project, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", ctx)
+	ret0, _ := ret[0].(iter.Seq2[*langfuse.Project, error])
+	return ret0
+}
+
 // ListProjects indicates an expected call of ListProjects.
-func (mr *MockOrganizationClientMockRecorder) ListProjects(arg0 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) ListProjects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjects), ctx)
+}
+
+// ListProjectsPage mocks base method.
+func (m *MockOrganizationClient) ListProjectsPage(ctx context.Context, page, limit int) (*langfuse.Page[*langfuse.Project], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectsPage", ctx, page, limit)
+	ret0, _ := ret[0].(*langfuse.Page[*langfuse.Project])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectsPage indicates an expected call of ListProjectsPage.
+func (mr *MockOrganizationClientMockRecorder) ListProjectsPage(ctx, page, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjects), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectsPage", reflect.TypeOf((*MockOrganizationClient)(nil).ListProjectsPage), ctx, page, limit)
 }
 
 // RemoveMember mocks base method.
-func (m *MockOrganizationClient) RemoveMember(arg0 context.Context, arg1 string) error {
+func (m *MockOrganizationClient) RemoveMember(ctx context.Context, membershipID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1)
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, membershipID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveMember indicates an expected call of RemoveMember.
-func (mr *MockOrganizationClientMockRecorder) RemoveMember(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) RemoveMember(ctx, membershipID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationClient)(nil).RemoveMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationClient)(nil).RemoveMember), ctx, membershipID)
 }
 
 // UpdateMembership mocks base method.
-func (m *MockOrganizationClient) UpdateMembership(arg0 context.Context, arg1 string, arg2 *langfuse.UpdateMembershipRequest) (*langfuse.OrganizationMembership, error) {
+func (m *MockOrganizationClient) UpdateMembership(ctx context.Context, membershipID string, request *langfuse.UpdateMembershipRequest) (*langfuse.OrganizationMembership, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMembership", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateMembership", ctx, membershipID, request)
 	ret0, _ := ret[0].(*langfuse.OrganizationMembership)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateMembership indicates an expected call of UpdateMembership.
-func (mr *MockOrganizationClientMockRecorder) UpdateMembership(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) UpdateMembership(ctx, membershipID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMembership", reflect.TypeOf((*MockOrganizationClient)(nil).UpdateMembership), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMembership", reflect.TypeOf((*MockOrganizationClient)(nil).UpdateMembership), ctx, membershipID, request)
 }
 
 // UpdateProject mocks base method.
-func (m *MockOrganizationClient) UpdateProject(arg0 context.Context, arg1 string, arg2 *langfuse.UpdateProjectRequest) (*langfuse.Project, error) {
+func (m *MockOrganizationClient) UpdateProject(ctx context.Context, projectID string, request *langfuse.UpdateProjectRequest) (*langfuse.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, projectID, request)
 	ret0, _ := ret[0].(*langfuse.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockOrganizationClientMockRecorder) UpdateProject(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrganizationClientMockRecorder) UpdateProject(ctx, projectID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockOrganizationClient)(nil).UpdateProject), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockOrganizationClient)(nil).UpdateProject), ctx, projectID, request)
 }
@@ -2,15 +2,17 @@ package langfuse
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 )
 
 var (
-	ErrMembershipNotFound        = errors.New("membership not found")
-	ErrProjectMembershipNotFound = errors.New("project membership not found")
+	ErrProjectNotFound           = fmt.Errorf("project %w", ErrNotFound)
+	ErrProjectApiKeyNotFound     = fmt.Errorf("project API key %w", ErrNotFound)
+	ErrMembershipNotFound        = fmt.Errorf("membership %w", ErrNotFound)
+	ErrProjectMembershipNotFound = fmt.Errorf("project membership %w", ErrNotFound)
 )
 
 type Project struct {
@@ -45,11 +47,13 @@ type UpdateProjectRequest struct {
 }
 
 type listProjectsResponse struct {
-	Projects []*Project `json:"projects"`
+	Projects []*Project     `json:"projects"`
+	Meta     PaginationMeta `json:"meta"`
 }
 
 type listProjectApiKeysResponse struct {
 	ApiKeys []ProjectApiKey `json:"apiKeys"`
+	Meta    PaginationMeta  `json:"meta"`
 }
 
 type deleteProjectResponse struct {
@@ -98,6 +102,7 @@ type UpdateMembershipRequest struct {
 
 type listMembershipsResponse struct {
 	Memberships []OrganizationMembership `json:"memberships"`
+	Meta        PaginationMeta           `json:"meta"`
 }
 
 type removeMemberResponse struct {
@@ -129,81 +134,81 @@ type deleteProjectMembershipResponse struct {
 
 type listProjectMembershipsResponse struct {
 	Memberships []ProjectMembership `json:"memberships"`
+	Meta        PaginationMeta      `json:"meta"`
 }
 
 //go:generate mockgen -destination=./mocks/mock_organization_client.go -package=mocks github.com/langfuse/terraform-provider-langfuse/internal/langfuse OrganizationClient
 
+// The List methods iterate over every page of a list endpoint, while their Page variants fetch a single one.
 type OrganizationClient interface {
-	ListProjects(ctx context.Context) ([]*Project, error)
+	ListProjects(ctx context.Context) iter.Seq2[*Project, error]
+	ListProjectsPage(ctx context.Context, page, limit int) (*Page[*Project], error)
 	GetProject(ctx context.Context, projectID string) (*Project, error)
 	CreateProject(ctx context.Context, request *CreateProjectRequest) (*Project, error)
 	UpdateProject(ctx context.Context, projectID string, request *UpdateProjectRequest) (*Project, error)
 	DeleteProject(ctx context.Context, projectID string) error
+	ListProjectApiKeys(ctx context.Context, projectID string) iter.Seq2[ProjectApiKey, error]
+	ListProjectApiKeysPage(ctx context.Context, projectID string, page, limit int) (*Page[ProjectApiKey], error)
 	GetProjectApiKey(ctx context.Context, projectID string, apiKeyID string) (*ProjectApiKey, error)
 	CreateProjectApiKey(ctx context.Context, projectID string, request *CreateProjectApiKeyRequest) (*ProjectApiKey, error)
 	DeleteProjectApiKey(ctx context.Context, projectID string, apiKeyID string) error
-	ListMemberships(ctx context.Context) ([]OrganizationMembership, error)
+	ListMemberships(ctx context.Context) iter.Seq2[OrganizationMembership, error]
+	ListMembershipsPage(ctx context.Context, page, limit int) (*Page[OrganizationMembership], error)
 	GetMembership(ctx context.Context, membershipID string) (*OrganizationMembership, error)
 	UpdateMembership(ctx context.Context, membershipID string, request *UpdateMembershipRequest) (*OrganizationMembership, error)
 	RemoveMember(ctx context.Context, membershipID string) error
 	CreateSCIMUser(ctx context.Context, request *SCIMUserRequest) (*SCIMUserResponse, error)
 	// Project membership methods
-	ListProjectMemberships(ctx context.Context, projectID string) ([]ProjectMembership, error)
+	ListProjectMemberships(ctx context.Context, projectID string) iter.Seq2[ProjectMembership, error]
+	ListProjectMembershipsPage(ctx context.Context, projectID string, page, limit int) (*Page[ProjectMembership], error)
 	GetProjectMembership(ctx context.Context, projectID, membershipID string) (*ProjectMembership, error)
 	CreateOrUpdateProjectMembership(ctx context.Context, projectID string, request *CreateProjectMembershipRequest) (*ProjectMembership, error)
 	DeleteProjectMembership(ctx context.Context, projectID, userID string) error
 }
 
 type organizationClientImpl struct {
-	host       string
-	publicKey  string
-	privateKey string
-	httpClient *http.Client
+	*apiClient
 }
 
 func NewOrganizationClient(host, publicKey, privateKey string) OrganizationClient {
-	return &organizationClientImpl{
-		host:       host,
-		publicKey:  publicKey,
-		privateKey: privateKey,
-		httpClient: &http.Client{},
-	}
+	return newOrganizationClient(newAPIClient(host, basicAuth{publicKey: publicKey, secretKey: privateKey}, newDefaultHTTPClient()))
 }
 
-func (c *organizationClientImpl) ListProjects(ctx context.Context) ([]*Project, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, "api/public/organizations/projects", nil)
-	if err != nil {
-		return nil, err
-	}
+func newOrganizationClient(client *apiClient) OrganizationClient {
+	return &organizationClientImpl{apiClient: client}
+}
+
+func (c *organizationClientImpl) ListProjects(ctx context.Context) iter.Seq2[*Project, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[*Project], error) {
+		return c.ListProjectsPage(ctx, page, DefaultPageSize)
+	})
+}
 
+func (c *organizationClientImpl) ListProjectsPage(ctx context.Context, page, limit int) (*Page[*Project], error) {
 	var listProjResp listProjectsResponse
-	if err := decodeResponse(resp, &listProjResp); err != nil {
+	if err := c.getJSON(ctx, collectionProjects, pagePath("api/public/organizations/projects", page, limit), &listProjResp); err != nil {
 		return nil, err
 	}
 
-	return listProjResp.Projects, nil
+	return &Page[*Project]{Items: listProjResp.Projects, Meta: listProjResp.Meta}, nil
 }
 
 func (c *organizationClientImpl) GetProject(ctx context.Context, projectID string) (*Project, error) {
 	// Note: this endpoint does not return `retentionDays`, so the returned value will always be 0
-	resp, err := c.makeRequest(ctx, http.MethodGet, "api/public/organizations/projects", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var listProjResp listProjectsResponse
-	if err := decodeResponse(resp, &listProjResp); err != nil {
-		return nil, err
-	}
-	for _, proj := range listProjResp.Projects {
+	for proj, err := range c.ListProjects(ctx) {
+		if err != nil {
+			return nil, err
+		}
 		if proj.ID == projectID {
 			return proj, nil
 		}
 	}
-	return nil, fmt.Errorf("cannot find project with ID %s", projectID)
+	return nil, fmt.Errorf("%w: ID %s", ErrProjectNotFound, projectID)
 }
 
 func (c *organizationClientImpl) CreateProject(ctx context.Context, request *CreateProjectRequest) (*Project, error) {
+	defer c.invalidate(collectionProjects)
+
 	resp, err := c.makeRequest(ctx, http.MethodPost, "api/public/projects", request)
 	if err != nil {
 		return nil, err
@@ -218,6 +223,8 @@ func (c *organizationClientImpl) CreateProject(ctx context.Context, request *Cre
 }
 
 func (c *organizationClientImpl) UpdateProject(ctx context.Context, projectID string, request *UpdateProjectRequest) (*Project, error) {
+	defer c.invalidate(collectionProjects)
+
 	resp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("api/public/projects/%s", projectID), request)
 	if err != nil {
 		return nil, err
@@ -232,6 +239,8 @@ func (c *organizationClientImpl) UpdateProject(ctx context.Context, projectID st
 }
 
 func (c *organizationClientImpl) DeleteProject(ctx context.Context, projectID string) error {
+	defer c.invalidate(collectionProjects, projectApiKeysCollection(projectID), projectMembershipsCollection(projectID))
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/public/projects/%s", projectID), nil)
 	if err != nil {
 		return err
@@ -248,26 +257,38 @@ func (c *organizationClientImpl) DeleteProject(ctx context.Context, projectID st
 	return nil
 }
 
-func (c *organizationClientImpl) GetProjectApiKey(ctx context.Context, projectID string, apiKeyID string) (*ProjectApiKey, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("api/public/projects/%s/apiKeys", projectID), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *organizationClientImpl) ListProjectApiKeys(ctx context.Context, projectID string) iter.Seq2[ProjectApiKey, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[ProjectApiKey], error) {
+		return c.ListProjectApiKeysPage(ctx, projectID, page, DefaultPageSize)
+	})
+}
 
+func (c *organizationClientImpl) ListProjectApiKeysPage(ctx context.Context, projectID string, page, limit int) (*Page[ProjectApiKey], error) {
+	apiPath := pagePath(fmt.Sprintf("api/public/projects/%s/apiKeys", projectID), page, limit)
 	var listProjApiKeysResp listProjectApiKeysResponse
-	if err := decodeResponse(resp, &listProjApiKeysResp); err != nil {
+	if err := c.getJSON(ctx, projectApiKeysCollection(projectID), apiPath, &listProjApiKeysResp); err != nil {
 		return nil, err
 	}
-	for _, key := range listProjApiKeysResp.ApiKeys {
+
+	return &Page[ProjectApiKey]{Items: listProjApiKeysResp.ApiKeys, Meta: listProjApiKeysResp.Meta}, nil
+}
+
+func (c *organizationClientImpl) GetProjectApiKey(ctx context.Context, projectID string, apiKeyID string) (*ProjectApiKey, error) {
+	for key, err := range c.ListProjectApiKeys(ctx, projectID) {
+		if err != nil {
+			return nil, err
+		}
 		if key.ID == apiKeyID {
 			return &key, nil
 		}
 	}
 
-	return nil, fmt.Errorf("cannot find API key with ID %s in project %s", apiKeyID, projectID)
+	return nil, fmt.Errorf("%w: ID %s in project %s", ErrProjectApiKeyNotFound, apiKeyID, projectID)
 }
 
 func (c *organizationClientImpl) CreateProjectApiKey(ctx context.Context, projectID string, request *CreateProjectApiKeyRequest) (*ProjectApiKey, error) {
+	defer c.invalidate(projectApiKeysCollection(projectID))
+
 	var body any = struct{}{}
 	if request != nil {
 		body = request
@@ -285,6 +306,8 @@ func (c *organizationClientImpl) CreateProjectApiKey(ctx context.Context, projec
 }
 
 func (c *organizationClientImpl) DeleteProjectApiKey(ctx context.Context, projectID string, apiKeyID string) error {
+	defer c.invalidate(projectApiKeysCollection(projectID))
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/public/projects/%s/apiKeys/%s", projectID, apiKeyID), nil)
 	if err != nil {
 		return err
@@ -301,27 +324,26 @@ func (c *organizationClientImpl) DeleteProjectApiKey(ctx context.Context, projec
 	return nil
 }
 
-func (c *organizationClientImpl) ListMemberships(ctx context.Context) ([]OrganizationMembership, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, "api/public/organizations/memberships", nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *organizationClientImpl) ListMemberships(ctx context.Context) iter.Seq2[OrganizationMembership, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[OrganizationMembership], error) {
+		return c.ListMembershipsPage(ctx, page, DefaultPageSize)
+	})
+}
 
+func (c *organizationClientImpl) ListMembershipsPage(ctx context.Context, page, limit int) (*Page[OrganizationMembership], error) {
 	var listMembershipsResp listMembershipsResponse
-	if err := decodeResponse(resp, &listMembershipsResp); err != nil {
+	if err := c.getJSON(ctx, collectionMemberships, pagePath("api/public/organizations/memberships", page, limit), &listMembershipsResp); err != nil {
 		return nil, err
 	}
 
-	return listMembershipsResp.Memberships, nil
+	return &Page[OrganizationMembership]{Items: listMembershipsResp.Memberships, Meta: listMembershipsResp.Meta}, nil
 }
 
 func (c *organizationClientImpl) GetMembership(ctx context.Context, membershipID string) (*OrganizationMembership, error) {
-	memberships, err := c.ListMemberships(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, membership := range memberships {
+	for membership, err := range c.ListMemberships(ctx) {
+		if err != nil {
+			return nil, err
+		}
 		// The API may not return the membership ID field, so check both ID and UserID
 		if membership.ID == membershipID || membership.UserID == membershipID {
 			return &membership, nil
@@ -349,6 +371,8 @@ func (c *organizationClientImpl) UpdateMembership(ctx context.Context, membershi
 		Role:   request.Role,
 	}
 
+	defer c.invalidate(collectionMemberships)
+
 	resp, err := c.makeRequest(ctx, http.MethodPut, "api/public/organizations/memberships", updateRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update membership: %w", err)
@@ -368,6 +392,8 @@ func (c *organizationClientImpl) UpdateMembership(ctx context.Context, membershi
 }
 
 func (c *organizationClientImpl) RemoveMember(ctx context.Context, membershipID string) error {
+	defer c.invalidate(collectionMemberships)
+
 	// DELETE endpoint requires userId in the request body
 	deleteRequest := struct {
 		UserID string `json:"userId"`
@@ -399,6 +425,9 @@ func (c *organizationClientImpl) CreateSCIMUser(ctx context.Context, request *SC
 		request.Active = true
 	}
 
+	// Creating a SCIM user adds it to the organization, so cached memberships are stale afterwards.
+	defer c.invalidate(collectionMemberships)
+
 	resp, err := c.makeRequest(ctx, http.MethodPost, "api/public/scim/Users", request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SCIM user: %w", err)
@@ -414,27 +443,27 @@ func (c *organizationClientImpl) CreateSCIMUser(ctx context.Context, request *SC
 
 // Project membership methods
 
-func (c *organizationClientImpl) ListProjectMemberships(ctx context.Context, projectID string) ([]ProjectMembership, error) {
-	resp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("api/public/projects/%s/memberships", projectID), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *organizationClientImpl) ListProjectMemberships(ctx context.Context, projectID string) iter.Seq2[ProjectMembership, error] {
+	return Paginate(ctx, func(ctx context.Context, page int) (*Page[ProjectMembership], error) {
+		return c.ListProjectMembershipsPage(ctx, projectID, page, DefaultPageSize)
+	})
+}
 
+func (c *organizationClientImpl) ListProjectMembershipsPage(ctx context.Context, projectID string, page, limit int) (*Page[ProjectMembership], error) {
+	apiPath := pagePath(fmt.Sprintf("api/public/projects/%s/memberships", projectID), page, limit)
 	var listResp listProjectMembershipsResponse
-	if err := decodeResponse(resp, &listResp); err != nil {
+	if err := c.getJSON(ctx, projectMembershipsCollection(projectID), apiPath, &listResp); err != nil {
 		return nil, err
 	}
 
-	return listResp.Memberships, nil
+	return &Page[ProjectMembership]{Items: listResp.Memberships, Meta: listResp.Meta}, nil
 }
 
 func (c *organizationClientImpl) GetProjectMembership(ctx context.Context, projectID, membershipID string) (*ProjectMembership, error) {
-	memberships, err := c.ListProjectMemberships(ctx, projectID)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, membership := range memberships {
+	for membership, err := range c.ListProjectMemberships(ctx, projectID) {
+		if err != nil {
+			return nil, err
+		}
 		if membership.UserID == membershipID {
 			return &membership, nil
 		}
@@ -444,6 +473,8 @@ func (c *organizationClientImpl) GetProjectMembership(ctx context.Context, proje
 }
 
 func (c *organizationClientImpl) CreateOrUpdateProjectMembership(ctx context.Context, projectID string, request *CreateProjectMembershipRequest) (*ProjectMembership, error) {
+	defer c.invalidate(projectMembershipsCollection(projectID))
+
 	resp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("api/public/projects/%s/memberships", projectID), request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/update project membership: %w", err)
@@ -462,6 +493,8 @@ func (c *organizationClientImpl) DeleteProjectMembership(ctx context.Context, pr
 		UserID: userID,
 	}
 
+	defer c.invalidate(projectMembershipsCollection(projectID))
+
 	resp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("api/public/projects/%s/memberships", projectID), deleteRequest)
 	if err != nil {
 		return err
@@ -477,18 +510,3 @@ func (c *organizationClientImpl) DeleteProjectMembership(ctx context.Context, pr
 
 	return nil
 }
-
-func (c *organizationClientImpl) makeRequest(ctx context.Context, methodType, apiPath string, body any) (*http.Response, error) {
-	req, err := buildBaseRequest(ctx, methodType, buildURL(c.host, apiPath), body)
-	if err != nil {
-		return nil, err
-	}
-	req.SetBasicAuth(c.publicKey, c.privateKey)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	return resp, nil
-}
@@ -0,0 +1,81 @@
+package langfuse
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of items requested per page by the list methods that iterate over all pages.
+const DefaultPageSize = 100
+
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+}
+
+// Page is a single page of a list endpoint. Meta is zero for endpoints that return everything at once.
+type Page[T any] struct {
+	Items []T
+	Meta  PaginationMeta
+}
+
+// PageFetcher fetches one page of a list endpoint. Pages are numbered from 1.
+type PageFetcher[T any] func(ctx context.Context, page int) (*Page[T], error)
+
+// Paginate iterates over the items of every page, fetching the next page only once the previous one has
+// been consumed. It stops after the last page announced by PaginationMeta, after an empty page, or after the
+// first page when the endpoint does not paginate. An error is yielded once, with a zero item, and ends the
+// iteration.
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 1; ; page++ {
+			result, err := fetch(ctx, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range result.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(result.Items) == 0 || result.Meta.TotalPages == 0 || page >= result.Meta.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// Collect gathers every item of seq, stopping at the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// pagePath adds the page and limit query parameters to apiPath. Zero values are left to the server's defaults.
+func pagePath(apiPath string, page, limit int) string {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if len(query) == 0 {
+		return apiPath
+	}
+	return apiPath + "?" + query.Encode()
+}
@@ -0,0 +1,127 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginateFollowsPaginationMeta(t *testing.T) {
+	t.Parallel()
+
+	var requested []int
+	fetch := func(ctx context.Context, page int) (*Page[int], error) {
+		requested = append(requested, page)
+		return &Page[int]{
+			Items: []int{page*10 + 1, page*10 + 2},
+			Meta:  PaginationMeta{Page: page, Limit: 2, TotalItems: 6, TotalPages: 3},
+		}, nil
+	}
+
+	items, err := Collect(Paginate(context.Background(), fetch))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(items) != "[11 12 21 22 31 32]" {
+		t.Fatalf("unexpected items: %v", items)
+	}
+	if fmt.Sprint(requested) != "[1 2 3]" {
+		t.Fatalf("unexpected pages requested: %v", requested)
+	}
+}
+
+func TestPaginateStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	var requested int
+	fetch := func(ctx context.Context, page int) (*Page[int], error) {
+		requested++
+		return &Page[int]{Items: []int{page}, Meta: PaginationMeta{TotalPages: 100}}, nil
+	}
+
+	for item := range Paginate(context.Background(), fetch) {
+		if item == 2 {
+			break
+		}
+	}
+	if requested != 2 {
+		t.Fatalf("expected pages to be fetched lazily, got %d requests", requested)
+	}
+}
+
+func TestPaginateWithoutPaginationMeta(t *testing.T) {
+	t.Parallel()
+
+	var requested int
+	fetch := func(ctx context.Context, page int) (*Page[string], error) {
+		requested++
+		return &Page[string]{Items: []string{"a", "b"}}, nil
+	}
+
+	items, err := Collect(Paginate(context.Background(), fetch))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || requested != 1 {
+		t.Fatalf("expected a single page with 2 items, got %v after %d requests", items, requested)
+	}
+}
+
+func TestPaginateYieldsErrors(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("boom")
+	fetch := func(ctx context.Context, page int) (*Page[int], error) {
+		if page == 2 {
+			return nil, failure
+		}
+		return &Page[int]{Items: []int{1}, Meta: PaginationMeta{TotalPages: 3}}, nil
+	}
+
+	var items []int
+	var errs []error
+	for item, err := range Paginate(context.Background(), fetch) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+	if len(items) != 1 || len(errs) != 1 || !errors.Is(errs[0], failure) {
+		t.Fatalf("expected 1 item then the error, got items %v and errors %v", items, errs)
+	}
+}
+
+func TestListProjectMembershipsFetchesEveryPage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != fmt.Sprint(DefaultPageSize) {
+			t.Errorf("unexpected limit: %q", r.URL.Query().Get("limit"))
+		}
+		page := r.URL.Query().Get("page")
+		_, _ = fmt.Fprintf(w, `{"memberships":[{"userId":"user-%s"}],"meta":{"page":%s,"limit":1,"totalItems":2,"totalPages":2}}`, page, page)
+	}))
+	defer server.Close()
+
+	client := NewOrganizationClient(server.URL, "pk-org", "sk-org")
+
+	memberships, err := Collect(client.ListProjectMemberships(context.Background(), "proj-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(memberships) != 2 || memberships[0].UserID != "user-1" || memberships[1].UserID != "user-2" {
+		t.Fatalf("unexpected memberships: %+v", memberships)
+	}
+
+	membership, err := client.GetProjectMembership(context.Background(), "proj-1", "user-2")
+	if err != nil {
+		t.Fatalf("expected the membership on the second page to be found: %v", err)
+	}
+	if membership.UserID != "user-2" {
+		t.Fatalf("unexpected membership: %+v", membership)
+	}
+}
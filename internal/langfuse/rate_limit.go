@@ -0,0 +1,158 @@
+package langfuse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig paces the requests sent with a single set of credentials. Zero values disable a limit.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained rate of request attempts, with bursts of up to one second's worth.
+	RequestsPerSecond float64
+	// MaxConcurrentRequests caps the number of requests in flight at the same time.
+	MaxConcurrentRequests int
+}
+
+// requestLimiter combines a token bucket with a semaphore for one set of credentials.
+type requestLimiter struct {
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+func newRequestLimiter(config RateLimitConfig) *requestLimiter {
+	l := &requestLimiter{}
+	if config.RequestsPerSecond > 0 {
+		burst := max(int(math.Ceil(config.RequestsPerSecond)), 1)
+		l.limiter = rate.NewLimiter(rate.Limit(config.RequestsPerSecond), burst)
+	}
+	if config.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	return l
+}
+
+// acquire blocks until the request may be sent. The returned function gives the concurrency slot back.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// limiterRegistry hands out one requestLimiter per set of credentials, so that all clients of a factory
+// using the same key pair share its budget.
+type limiterRegistry struct {
+	config RateLimitConfig
+
+	mu       sync.Mutex
+	limiters map[string]*requestLimiter
+}
+
+func newLimiterRegistry(config RateLimitConfig) *limiterRegistry {
+	return &limiterRegistry{
+		config:   config,
+		limiters: make(map[string]*requestLimiter),
+	}
+}
+
+// forCredentials returns the limiter of the given credentials, or nil when no limit is configured.
+func (r *limiterRegistry) forCredentials(credentials ...string) *requestLimiter {
+	if r == nil || (r.config.RequestsPerSecond <= 0 && r.config.MaxConcurrentRequests <= 0) {
+		return nil
+	}
+	key := credentialsKey(credentials...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limiter, ok := r.limiters[key]
+	if !ok {
+		limiter = newRequestLimiter(r.config)
+		r.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// credentialsKey identifies a set of credentials without keeping the secrets themselves around.
+func credentialsKey(credentials ...string) string {
+	hash := sha256.New()
+	for _, credential := range credentials {
+		_, _ = io.WriteString(hash, credential)
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type requestLimiterKey struct{}
+
+func withRequestLimiter(ctx context.Context, limiter *requestLimiter) context.Context {
+	return context.WithValue(ctx, requestLimiterKey{}, limiter)
+}
+
+// rateLimitTransport applies the limiter attached to the request's context to every attempt, so that
+// retries are paced like any other request. The concurrency slot is held until the response body is closed.
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{next: next}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter, ok := req.Context().Value(requestLimiterKey{}).(*requestLimiter)
+	if !ok || limiter == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	release, err := limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releaseOnCloseBody gives the concurrency slot back once, when the caller is done with the response body.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,89 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitCapsConcurrentRequestsPerCredentials(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"memberships":[]}`))
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "", WithRateLimit(RateLimitConfig{MaxConcurrentRequests: 2}))
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every resource creates its own client, but they all share the budget of the key pair.
+			client := factory.NewOrganizationClient("", "pk-org", "sk-org")
+			if _, err := Collect(client.ListProjectMemberships(context.Background(), strconv.Itoa(i))); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", got)
+	}
+}
+
+func TestRateLimitPacesRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"memberships":[]}`))
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(server.URL, "", WithRateLimit(RateLimitConfig{RequestsPerSecond: 50}))
+	client := factory.NewOrganizationClient("", "pk-org", "sk-org")
+
+	// The first 50 requests use the burst, the next 10 are paced at 50 per second.
+	start := time.Now()
+	for i := range 60 {
+		if _, err := Collect(client.ListProjectMemberships(context.Background(), strconv.Itoa(i))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected requests to be paced, 60 requests took %s", elapsed)
+	}
+}
+
+func TestLimiterRegistrySeparatesCredentials(t *testing.T) {
+	t.Parallel()
+
+	registry := newLimiterRegistry(RateLimitConfig{RequestsPerSecond: 1})
+	if registry.forCredentials("pk-1", "sk-1") != registry.forCredentials("pk-1", "sk-1") {
+		t.Fatalf("expected the same credentials to share a limiter")
+	}
+	if registry.forCredentials("pk-1", "sk-1") == registry.forCredentials("pk-2", "sk-2") {
+		t.Fatalf("expected different credentials to get their own limiter")
+	}
+	if newLimiterRegistry(RateLimitConfig{}).forCredentials("pk-1", "sk-1") != nil {
+		t.Fatalf("expected no limiter when no limit is configured")
+	}
+}
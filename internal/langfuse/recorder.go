@@ -0,0 +1,236 @@
+package langfuse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderModeEnvVar selects the mode of the recorders created by the acceptance tests.
+const RecorderModeEnvVar = "LANGFUSE_RECORDER_MODE"
+
+// RecorderMode tells a Recorder whether to talk to a live server, record the exchanges or replay them.
+type RecorderMode string
+
+const (
+	// RecorderModeLive sends requests to the server without recording them.
+	RecorderModeLive RecorderMode = "live"
+	// RecorderModeRecord sends requests to the server and records the exchanges into the cassette.
+	RecorderModeRecord RecorderMode = "record"
+	// RecorderModeReplay answers requests from the cassette without any network access.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// ParseRecorderMode parses the value of LANGFUSE_RECORDER_MODE. An empty value means live.
+func ParseRecorderMode(value string) (RecorderMode, error) {
+	switch mode := RecorderMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "", RecorderModeLive:
+		return RecorderModeLive, nil
+	case RecorderModeRecord, RecorderModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid recorder mode %q, expected one of %q, %q or %q", value, RecorderModeLive, RecorderModeRecord, RecorderModeReplay)
+	}
+}
+
+// errInteractionNotRecorded is returned in replay mode for a request that is missing from the cassette.
+// Such a request is never retried, since it would miss again.
+var errInteractionNotRecorded = errors.New("no recorded interaction matches the request")
+
+// cassette is the file format of the recorded exchanges. Credentials are scrubbed before anything is
+// stored: request headers are not recorded at all, and the secrets of JSON bodies are masked as in logs.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	// URI is the path and query of the request. The host is left out, so that a cassette recorded against
+	// one instance can be replayed with any host configured.
+	URI  string `json:"uri"`
+	Body string `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// recordedResponseHeaders are the only response headers kept in cassettes, the ones the clients read.
+var recordedResponseHeaders = []string{"Content-Type", "Retry-After"}
+
+// Recorder records the exchanges with a Langfuse server into a cassette file and replays them, so that
+// provider tests can run without a server. Its Middleware plugs into a ClientFactory with WithMiddleware.
+type Recorder struct {
+	mode         RecorderMode
+	cassettePath string
+
+	mu           sync.Mutex
+	interactions []interaction
+	replayed     []bool
+}
+
+// NewRecorder creates a recorder for the cassette at cassettePath. In replay mode the cassette must exist.
+func NewRecorder(mode RecorderMode, cassettePath string) (*Recorder, error) {
+	r := &Recorder{mode: mode, cassettePath: cassettePath}
+	if mode != RecorderModeReplay {
+		return r, nil
+	}
+
+	content, err := os.ReadFile(cassettePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var recorded cassette
+	if err := json.Unmarshal(content, &recorded); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", cassettePath, err)
+	}
+	r.interactions = recorded.Interactions
+	r.replayed = make([]bool, len(recorded.Interactions))
+
+	return r, nil
+}
+
+// Mode returns the mode the recorder was created with.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Middleware records or replays the requests sent through it. It is a no-op in live mode.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	switch r.mode {
+	case RecorderModeRecord:
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return r.record(next, req)
+		})
+	case RecorderModeReplay:
+		return RoundTripperFunc(r.replay)
+	default:
+		return next
+	}
+}
+
+// Save writes the recorded exchanges to the cassette. It only has an effect in record mode.
+func (r *Recorder) Save() error {
+	if r.mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	content, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.cassettePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.cassettePath, append(content, '\n'), 0o644)
+}
+
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	recordedReq := newRecordedRequest(req)
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := make(map[string]string)
+	for _, name := range recordedResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction{
+		Request: recordedReq,
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       string(scrubBody(body)),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay answers with the first interaction not replayed yet that matches the request's method, URI and
+// scrubbed body. Requests that Terraform sends in parallel may therefore arrive in any order, while
+// repeated identical requests get their responses in the recorded order.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	wanted := newRecordedRequest(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, recorded := range r.interactions {
+		if r.replayed[i] || recorded.Request != wanted {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := make(http.Header)
+		for name, value := range recorded.Response.Headers {
+			header.Set(name, value)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+			StatusCode:    recorded.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(recorded.Response.Body)),
+			ContentLength: int64(len(recorded.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", errInteractionNotRecorded, wanted.Method, wanted.URI)
+}
+
+func newRecordedRequest(req *http.Request) recordedRequest {
+	return recordedRequest{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+		Body:   string(scrubBody(peekRequestBody(req))),
+	}
+}
+
+// scrubBody masks credentials in a JSON body and normalises its formatting. Other bodies are returned as they are.
+func scrubBody(body []byte) []byte {
+	var payload any
+	if len(body) == 0 || json.Unmarshal(body, &payload) != nil {
+		return body
+	}
+
+	scrubbed, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
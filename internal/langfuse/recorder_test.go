@@ -0,0 +1,115 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRecorderMode(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]RecorderMode{
+		"":        RecorderModeLive,
+		"live":    RecorderModeLive,
+		"RECORD":  RecorderModeRecord,
+		" replay": RecorderModeReplay,
+	} {
+		got, err := ParseRecorderMode(value)
+		if err != nil || got != want {
+			t.Errorf("ParseRecorderMode(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := ParseRecorderMode("rewind"); err == nil {
+		t.Errorf("expected an error for an unknown mode")
+	}
+}
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/api/public/llm-connections":
+			_, _ = w.Write([]byte(`{"id":"conn-1","provider":"openai","adapter":"openai","displaySecretKey":"...cret"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/public/llm-connections":
+			_, _ = w.Write([]byte(`{"data":[{"id":"conn-1","provider":"openai","adapter":"openai"}],"meta":{"page":1,"limit":100,"totalItems":1,"totalPages":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "llm.json")
+	upsert := &UpsertLlmConnectionRequest{Adapter: "openai", Provider: "openai", SecretKey: "sk-provider-secret"}
+	ctx := context.Background()
+
+	recorder, err := NewRecorder(RecorderModeRecord, cassettePath)
+	if err != nil {
+		t.Fatalf("unexpected error creating recorder: %v", err)
+	}
+	recordingClient := NewClientFactory(server.URL, "", WithMiddleware(recorder.Middleware)).
+		NewLlmConnectionsClient("", "pk-project", "sk-project")
+	if _, err := recordingClient.UpsertLlmConnection(ctx, upsert); err != nil {
+		t.Fatalf("unexpected error recording upsert: %v", err)
+	}
+	if _, err := Collect(recordingClient.ListLlmConnections(ctx)); err != nil {
+		t.Fatalf("unexpected error recording list: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error saving cassette: %v", err)
+	}
+
+	content, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("unexpected error reading cassette: %v", err)
+	}
+	for _, secret := range []string{"sk-provider-secret", "sk-project", "Authorization", "Basic "} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, content)
+		}
+	}
+
+	// Replaying works without the server, whichever host is configured.
+	replayer, err := NewRecorder(RecorderModeReplay, cassettePath)
+	if err != nil {
+		t.Fatalf("unexpected error loading cassette: %v", err)
+	}
+	replayingClient := NewClientFactory("http://langfuse.invalid", "", WithMiddleware(replayer.Middleware)).
+		NewLlmConnectionsClient("", "pk-other", "sk-other")
+
+	connections, err := Collect(replayingClient.ListLlmConnections(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error replaying list: %v", err)
+	}
+	if len(connections) != 1 || connections[0].ID != "conn-1" {
+		t.Fatalf("unexpected replayed connections: %+v", connections)
+	}
+	connection, err := replayingClient.UpsertLlmConnection(ctx, upsert)
+	if err != nil {
+		t.Fatalf("unexpected error replaying upsert: %v", err)
+	}
+	if connection.ID != "conn-1" {
+		t.Fatalf("unexpected replayed connection: %+v", connection)
+	}
+
+	// Every interaction is replayed once.
+	if _, err := replayingClient.UpsertLlmConnection(ctx, upsert); !errors.Is(err, errInteractionNotRecorded) {
+		t.Fatalf("expected a second upsert to miss the cassette, got %v", err)
+	}
+}
+
+func TestNewRecorderRequiresCassetteForReplay(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewRecorder(RecorderModeReplay, filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a missing cassette error, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package langfuse
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried when no other value is configured.
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxWait is the upper bound for the wait between two attempts when no other value is configured.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryMinWait = 1 * time.Second
+)
+
+var errBodyNotRewindable = errors.New("request body cannot be replayed")
+
+// RetryConfig controls how requests failing with a rate limit, a server error or a network error are retried.
+type RetryConfig struct {
+	// MaxRetries is the number of additional attempts after the first one. Zero disables retries.
+	MaxRetries int
+	// MaxWait caps the wait between two attempts, including waits requested through Retry-After.
+	MaxWait time.Duration
+}
+
+// DefaultRetryConfig returns the retry settings used when the provider configuration does not override them.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: DefaultMaxRetries,
+		MaxWait:    DefaultRetryMaxWait,
+	}
+}
+
+// retryTransport retries requests with exponential backoff and jitter. GET, PUT and DELETE requests are
+// idempotent and are retried on 429, 5xx and network errors. POST requests are only retried on 429, because
+// the server rejected them before doing any work.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, config RetryConfig) *retryTransport {
+	maxWait := config.MaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+	minWait := retryMinWait
+	if minWait > maxWait {
+		minWait = maxWait
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: max(config.MaxRetries, 0),
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// The body of the original request has been consumed, so a retry is only possible when it can be rebuilt.
+		nextReq, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attemptReq = nextReq
+	}
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After header sent by the server takes
+// precedence over the exponential schedule, but both are capped at maxWait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, t.maxWait)
+		}
+	}
+
+	wait := t.minWait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	// Equal jitter: keep half of the computed wait and randomise the other half so that parallel
+	// resources hitting the same limit do not retry in lockstep.
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method) && !errors.Is(err, errInteractionNotRecorded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errBodyNotRewindable
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+
+	return newReq, nil
+}
+
+// parseRetryAfter understands both forms allowed by RFC 9110: a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,162 @@
+package langfuse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportRetriesIdempotentRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"test"}` {
+			t.Errorf("unexpected body on attempt %d: %q", calls.Load()+1, string(body))
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond})}
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"test"}`))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code. got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("unexpected number of attempts. got %d, want 3", calls.Load())
+	}
+}
+
+func TestRetryTransportDoesNotRetryPostOnServerError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond})}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Fatalf("POST must not be retried on 503. got %d attempts", calls.Load())
+	}
+}
+
+func TestRetryTransportRetriesPostOnTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 1, MaxWait: time.Millisecond})}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated || calls.Load() != 2 {
+		t.Fatalf("expected POST to succeed on second attempt. got status %d after %d attempts", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 2, MaxWait: time.Millisecond})}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status code. got %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("unexpected number of attempts. got %d, want 3", calls.Load())
+	}
+}
+
+func TestRetryTransportStopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 5, MaxWait: time.Minute})}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	if _, err := client.Do(req); err == nil {
+		t.Fatalf("expected an error once the context is cancelled")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	if wait, ok := parseRetryAfter("7"); !ok || wait != 7*time.Second {
+		t.Fatalf("unexpected result for seconds. got %v, %v", wait, ok)
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > 10*time.Second {
+		t.Fatalf("unexpected result for HTTP date. got %v, %v", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Fatalf("expected invalid value to be rejected")
+	}
+}
@@ -0,0 +1,58 @@
+package langfuse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Requirement describes what a resource needs from the Langfuse deployment it is applied to.
+type Requirement struct {
+	// Feature names the API the resource relies on, for error messages.
+	Feature string
+	// SelfHostedOnly is set for the APIs that Langfuse Cloud does not expose.
+	SelfHostedOnly bool
+}
+
+// RequireAdminAPI covers the instance management API used to create organizations and their API keys. It is
+// authenticated with the ADMIN_API_KEY of the instance, as in testdata/docker-compose.yml, which only
+// self-hosted deployments can set.
+var RequireAdminAPI = Requirement{Feature: "the admin API", SelfHostedOnly: true}
+
+// ServerInfo describes the Langfuse deployment a ClientFactory talks to.
+type ServerInfo struct {
+	// Cloud is set when the host is Langfuse Cloud rather than a self-hosted instance.
+	Cloud bool
+}
+
+func newServerInfo(host string) *ServerInfo {
+	return &ServerInfo{Cloud: isCloudHost(host)}
+}
+
+// Check returns a RequirementError when the deployment cannot satisfy requirement.
+func (i *ServerInfo) Check(requirement Requirement) error {
+	if requirement.SelfHostedOnly && i.Cloud {
+		return &RequirementError{Requirement: requirement}
+	}
+	return nil
+}
+
+// RequirementError reports a deployment that cannot serve a resource. Its message is meant to follow the
+// resource type, e.g. "langfuse_organization requires a self-hosted Langfuse instance ...".
+type RequirementError struct {
+	Requirement Requirement
+}
+
+func (e *RequirementError) Error() string {
+	return fmt.Sprintf("requires a self-hosted Langfuse instance, because %s is not available on Langfuse Cloud", e.Requirement.Feature)
+}
+
+// isCloudHost reports whether host points to Langfuse Cloud, in any of its regions.
+func isCloudHost(host string) bool {
+	parsed, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+	hostname := strings.ToLower(parsed.Hostname())
+	return hostname == "langfuse.com" || strings.HasSuffix(hostname, ".langfuse.com")
+}
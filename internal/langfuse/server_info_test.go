@@ -0,0 +1,84 @@
+package langfuse
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestServerInfoCheck(t *testing.T) {
+	t.Parallel()
+
+	requireAnyDeployment := Requirement{Feature: "the public API"}
+
+	tests := []struct {
+		name        string
+		host        string
+		requirement Requirement
+		wantErr     string
+	}{
+		{
+			name:        "self-hosted",
+			host:        "https://langfuse.example.com",
+			requirement: RequireAdminAPI,
+		},
+		{
+			name:        "self-hosted only on cloud",
+			host:        "https://us.cloud.langfuse.com",
+			requirement: RequireAdminAPI,
+			wantErr:     "requires a self-hosted Langfuse instance",
+		},
+		{
+			name:        "self-hosted only on a look-alike host",
+			host:        "https://notlangfuse.com",
+			requirement: RequireAdminAPI,
+		},
+		{
+			name:        "any deployment on cloud",
+			host:        "https://cloud.langfuse.com",
+			requirement: requireAnyDeployment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newServerInfo(tt.host).Check(tt.requirement)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var requirementErr *RequirementError
+			if !errors.As(err, &requirementErr) {
+				t.Fatalf("expected a RequirementError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestClientFactoryServerInfoDoesNotContactTheHost(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	if info := NewClientFactory(server.URL, "").ServerInfo(); info.Cloud {
+		t.Fatalf("expected %s to be self-hosted", server.URL)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("expected no request, got %d", calls.Load())
+	}
+}
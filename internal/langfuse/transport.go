@@ -0,0 +1,168 @@
+package langfuse
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a single attempt of a request when no other value is configured.
+const DefaultRequestTimeout = 60 * time.Second
+
+// Middleware wraps the transport used by the clients, e.g. to add logging or tracing.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newDefaultTransport returns a transport with the same settings as http.DefaultTransport but its own
+// connection pool, so that per-factory settings never leak into the process-wide default.
+func newDefaultTransport() *http.Transport {
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// buildTransportChain assembles the transport shared by all clients of a factory. The retry layer is the
+// outermost one so that every attempt is rate limited, logged, goes through the middlewares and gets its own timeout.
+func buildTransportChain(base http.RoundTripper, middlewares []Middleware, timeout time.Duration, retryConfig RetryConfig) http.RoundTripper {
+	transport := base
+	if timeout > 0 {
+		transport = newTimeoutTransport(transport, timeout)
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	transport = newLoggingTransport(transport)
+	transport = newRateLimitTransport(transport)
+
+	return newRetryTransport(transport, retryConfig)
+}
+
+// timeoutTransport bounds each attempt of a request, from sending it until its body has been read.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	return &timeoutTransport{next: next, timeout: timeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the attempt's context once the caller is done with the response body.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// TransportConfig describes how the base transport reaches a Langfuse host, typically a self-hosted one
+// behind a corporate proxy or a private certificate authority.
+type TransportConfig struct {
+	// CACertFile and CACertPEM add trusted certificate authorities on top of the system pool.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey enable mutual TLS. Each accepts either PEM-encoded content or a file path.
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	// ProxyURL overrides the proxy otherwise taken from HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+	ProxyURL string
+}
+
+// NewTransport builds a base transport for WithTransport from the given TLS and proxy settings.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := newDefaultTransport()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+	}
+
+	if config.CACertFile != "" || config.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if config.CACertFile != "" {
+			pem, err := os.ReadFile(config.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificate found in %s", config.CACertFile)
+			}
+		}
+		if config.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, errors.New("no valid PEM certificate found in the CA certificate content")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+		certPEM, err := readPEM(config.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// readPEM returns value itself when it holds PEM-encoded content, and the content of the file it points to otherwise.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
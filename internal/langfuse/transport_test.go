@@ -0,0 +1,69 @@
+package langfuse
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewTransportTrustsConfiguredCA(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	untrusted, err := NewTransport(TransportConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error building default transport: %v", err)
+	}
+	if _, err := (&http.Client{Transport: untrusted}).Get(server.URL); err == nil {
+		t.Fatalf("expected the self-signed certificate to be rejected without a custom CA")
+	}
+
+	trusted, err := NewTransport(TransportConfig{CACertPEM: string(caPEM)})
+	if err != nil {
+		t.Fatalf("unexpected error building transport with CA: %v", err)
+	}
+	resp, err := (&http.Client{Transport: trusted}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the configured CA to be trusted: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewTransportRejectsInvalidSettings(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewTransport(TransportConfig{CACertPEM: "not a certificate"}); err == nil {
+		t.Fatalf("expected invalid CA content to be rejected")
+	}
+	if _, err := NewTransport(TransportConfig{ClientCert: "-----BEGIN CERTIFICATE-----"}); err == nil {
+		t.Fatalf("expected a client certificate without a key to be rejected")
+	}
+	if _, err := NewTransport(TransportConfig{CACertFile: "/does/not/exist.pem"}); err == nil {
+		t.Fatalf("expected a missing CA file to be rejected")
+	}
+}
+
+func TestNewTransportUsesProxyURL(t *testing.T) {
+	t.Parallel()
+
+	transport, err := NewTransport(TransportConfig{ProxyURL: "http://proxy.internal:3128"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "langfuse.internal"}})
+	if err != nil {
+		t.Fatalf("unexpected proxy error: %v", err)
+	}
+	if proxy == nil || proxy.Host != "proxy.internal:3128" {
+		t.Fatalf("unexpected proxy: %v", proxy)
+	}
+}
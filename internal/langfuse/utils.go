@@ -29,17 +29,32 @@ func buildBaseRequest(ctx context.Context, method, url string, body any) (*http.
 }
 
 func decodeResponse(resp *http.Response, target any) error {
+	body, err := readResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalBody(body, target)
+}
+
+// readResponse returns the body of a successful response and an APIError for any other status code.
+func readResponse(resp *http.Response) ([]byte, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("request failed with status code %d, response body: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body)
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
-	if err = json.Unmarshal(body, &target); err != nil {
+
+	return body, nil
+}
+
+func unmarshalBody(body []byte, target any) error {
+	if err := json.Unmarshal(body, &target); err != nil {
 		return fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
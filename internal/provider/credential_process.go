@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+// credentialProcessTimeout bounds a credential process, which may be waiting for a secrets manager.
+const credentialProcessTimeout = time.Minute
+
+// credentialProcesses caches the output of the credential processes run by this provider process, so that a
+// secrets manager is queried once per run even when several provider configurations use the same command.
+var credentialProcesses = &credentialProcessCache{}
+
+type credentialProcessCache struct {
+	mu      sync.Mutex
+	results map[string]credentialsProfile
+}
+
+// credentialProcessOutput is the JSON document a credential process writes to its standard output. Every
+// field is optional.
+type credentialProcessOutput struct {
+	Host                   string `json:"host"`
+	AdminAPIKey            string `json:"admin_api_key"`
+	OrganizationPublicKey  string `json:"organization_public_key"`
+	OrganizationPrivateKey string `json:"organization_private_key"`
+	ProjectPublicKey       string `json:"project_public_key"`
+	ProjectSecretKey       string `json:"project_secret_key"`
+}
+
+// run returns the output of command, running it only the first time it is requested. Failures are not
+// cached, so that a later configuration can retry.
+func (c *credentialProcessCache) run(ctx context.Context, command string) (credentialsProfile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if result, ok := c.results[command]; ok {
+		return result, nil
+	}
+	result, err := runCredentialProcess(ctx, command)
+	if err != nil {
+		return credentialsProfile{}, err
+	}
+	if c.results == nil {
+		c.results = map[string]credentialsProfile{}
+	}
+	c.results[command] = result
+	return result, nil
+}
+
+// runCredentialProcess runs command through the shell, like the credential_process of the AWS CLI, and
+// parses the credentials it prints. Its standard error is only used to report failures, and its output is
+// never logged since it holds secrets.
+func runCredentialProcess(ctx context.Context, command string) (credentialsProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return credentialsProfile{}, fmt.Errorf("credential process timed out after %s", credentialProcessTimeout)
+		}
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return credentialsProfile{}, fmt.Errorf("credential process failed: %w: %s", err, detail)
+		}
+		return credentialsProfile{}, fmt.Errorf("credential process failed: %w", err)
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		// The parsing error is left out, since it may quote the output, which holds secrets.
+		return credentialsProfile{}, errors.New("credential process did not print a valid JSON object")
+	}
+
+	profile := credentialsProfile{
+		Host:        output.Host,
+		AdminAPIKey: output.AdminAPIKey,
+		Organization: langfuse.Credentials{
+			PublicKey: output.OrganizationPublicKey,
+			SecretKey: output.OrganizationPrivateKey,
+		},
+		Project: langfuse.Credentials{
+			PublicKey: output.ProjectPublicKey,
+			SecretKey: output.ProjectSecretKey,
+		},
+	}
+	if err := profile.checkKeyPairs(); err != nil {
+		return credentialsProfile{}, fmt.Errorf("credential process output %w", err)
+	}
+	return profile, nil
+}
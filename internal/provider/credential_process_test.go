@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+func TestRunCredentialProcess(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands of this test need a POSIX shell")
+	}
+
+	tests := []struct {
+		name     string
+		command  string
+		expected credentialsProfile
+		errorMsg string
+	}{
+		{
+			name:    "all_fields",
+			command: `printf '{"host":"https://cloud.langfuse.com","admin_api_key":"admin-key","organization_public_key":"pk-org","organization_private_key":"sk-org","project_public_key":"pk-project","project_secret_key":"sk-project","expires":"later"}'`,
+			expected: credentialsProfile{
+				Host:         "https://cloud.langfuse.com",
+				AdminAPIKey:  "admin-key",
+				Organization: langfuse.Credentials{PublicKey: "pk-org", SecretKey: "sk-org"},
+				Project:      langfuse.Credentials{PublicKey: "pk-project", SecretKey: "sk-project"},
+			},
+		},
+		{
+			name:     "admin_key_only",
+			command:  `echo '{"admin_api_key":"admin-key"}'`,
+			expected: credentialsProfile{AdminAPIKey: "admin-key"},
+		},
+		{
+			name:     "failure",
+			command:  `echo "vault: permission denied" >&2; exit 3`,
+			errorMsg: "credential process failed: exit status 3: vault: permission denied",
+		},
+		{
+			name:     "invalid_json",
+			command:  `echo 'sk-secret'`,
+			errorMsg: "credential process did not print a valid JSON object",
+		},
+		{
+			name:     "incomplete_keys",
+			command:  `echo '{"project_public_key":"pk-project"}'`,
+			errorMsg: "must set project_public_key and project_secret_key together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile, err := runCredentialProcess(context.Background(), tt.command)
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("expected an error containing %q, got %v", tt.errorMsg, err)
+				}
+				if strings.Contains(err.Error(), "sk-secret") {
+					t.Fatalf("the error must not quote the output of the process: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if profile != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, profile)
+			}
+		})
+	}
+}
+
+func TestCredentialProcessCacheRunsCommandsOnce(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands of this test need a POSIX shell")
+	}
+
+	runs := filepath.Join(t.TempDir(), "runs")
+	command := `echo run >> '` + runs + `'; echo '{"admin_api_key":"admin-key"}'`
+
+	var cache credentialProcessCache
+	for range 3 {
+		profile, err := cache.run(context.Background(), command)
+		if err != nil || profile.AdminAPIKey != "admin-key" {
+			t.Fatalf("run() = %+v, %v", profile, err)
+		}
+	}
+
+	content, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(string(content), "run"); count != 1 {
+		t.Fatalf("expected the command to run once, ran %d times", count)
+	}
+
+	if _, err := cache.run(context.Background(), "exit 1"); err == nil {
+		t.Fatalf("expected a failing command to fail")
+	}
+	if _, ok := cache.results["exit 1"]; ok {
+		t.Fatalf("failures must not be cached")
+	}
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+// defaultProfileName is the profile read from the credentials file when the provider does not select one.
+const defaultProfileName = "default"
+
+// credentialsProfile is a named section of the Langfuse credentials file, used for the settings that are set
+// neither in the provider configuration nor in the environment. It also holds the output of a credential
+// process.
+type credentialsProfile struct {
+	Host         string
+	AdminAPIKey  string
+	Organization langfuse.Credentials
+	Project      langfuse.Credentials
+}
+
+// credentialsFileSelected reports whether the provider uses the credentials file, which is only read when a
+// profile or the path of the file is set, so that the home directory of a CI runner is never looked up on
+// its own.
+func credentialsFileSelected(profileName string) bool {
+	return profileName != "" || os.Getenv("LANGFUSE_CREDENTIALS_FILE") != ""
+}
+
+// credentialsFilePath returns LANGFUSE_CREDENTIALS_FILE, or ~/.config/langfuse/credentials when it is not set.
+// It returns an empty path when the home directory is unknown, such as when HOME is not set.
+func credentialsFilePath() string {
+	if path := os.Getenv("LANGFUSE_CREDENTIALS_FILE"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "langfuse", "credentials")
+}
+
+// loadCredentialsProfile reads the named profile from the credentials file at path. Without a name, the
+// default profile is read if there is one. A missing file, or an empty path, holds no profiles, which is only
+// an error when a profile is named.
+func loadCredentialsProfile(path, name string) (credentialsProfile, error) {
+	selected := name != ""
+	if !selected {
+		name = defaultProfileName
+	}
+
+	profiles, err := readCredentialsFile(path)
+	if err != nil {
+		return credentialsProfile{}, err
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		if !selected {
+			return credentialsProfile{}, nil
+		}
+		if path == "" {
+			return credentialsProfile{}, fmt.Errorf("profile %q not found: the home directory is unknown, set LANGFUSE_CREDENTIALS_FILE to the path of the credentials file", name)
+		}
+		return credentialsProfile{}, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	profile := credentialsProfile{
+		Host:        values["host"],
+		AdminAPIKey: values["admin_api_key"],
+		Organization: langfuse.Credentials{
+			PublicKey: values["organization_public_key"],
+			SecretKey: values["organization_private_key"],
+		},
+		Project: langfuse.Credentials{
+			PublicKey: values["project_public_key"],
+			SecretKey: values["project_secret_key"],
+		},
+	}
+	if err := profile.checkKeyPairs(); err != nil {
+		return credentialsProfile{}, fmt.Errorf("profile %q in %s %w", name, path, err)
+	}
+	return profile, nil
+}
+
+// readCredentialsFile parses the credentials file at path, returning no profiles when the path is empty or the
+// file does not exist.
+func readCredentialsFile(path string) (map[string]map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the Langfuse credentials file: %w", err)
+	}
+	defer file.Close()
+
+	profiles, err := parseCredentialsFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return profiles, nil
+}
+
+// checkKeyPairs fails when only one key of the organization or project pair is set.
+func (p credentialsProfile) checkKeyPairs() error {
+	if (p.Organization.PublicKey == "") != (p.Organization.SecretKey == "") {
+		return errors.New("must set organization_public_key and organization_private_key together")
+	}
+	if (p.Project.PublicKey == "") != (p.Project.SecretKey == "") {
+		return errors.New("must set project_public_key and project_secret_key together")
+	}
+	return nil
+}
+
+// parseCredentialsFile reads the profiles of a credentials file, keyed by name. It accepts the subset of INI
+// and TOML shared by such files: [profile] headers followed by key = value lines, where values may be bare or
+// quoted, and lines starting with # or ; are comments. Unknown keys are kept, so that the file can be shared
+// with other Langfuse tools.
+func parseCredentialsFile(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			name, rest, ok := strings.Cut(line[1:], "]")
+			if !ok || !isComment(rest) {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", lineNumber, line)
+			}
+			name, err := unquote(strings.TrimSpace(name))
+			if err != nil || name == "" {
+				return nil, fmt.Errorf("line %d: invalid profile name in %q", lineNumber, line)
+			}
+			if _, ok := profiles[name]; ok {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", lineNumber, name)
+			}
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: %s is not in a profile", lineNumber, strings.TrimSpace(key))
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value of %s: %w", lineNumber, key, err)
+		}
+		if _, ok := current[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %s", lineNumber, key)
+		}
+		current[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// parseValue returns a quoted value without its quotes, or a bare value without a trailing comment.
+func parseValue(value string) (string, error) {
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		if i := strings.Index(value, " ;"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	quote, end := value[0], 0
+	for i := 1; i < len(value) && end == 0; i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			// Skip the character escaped within a basic string.
+			i++
+		case value[i] == quote:
+			end = i
+		}
+	}
+	if end == 0 {
+		return "", errors.New("missing closing quote")
+	}
+	if !isComment(value[end+1:]) {
+		return "", fmt.Errorf("unexpected text after the closing quote: %q", value[end+1:])
+	}
+	return unquote(value[:end+1])
+}
+
+// unquote removes the double quotes of a basic string, interpreting its escapes, or the single quotes of a
+// literal string. Other values are returned unchanged.
+func unquote(value string) (string, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	}
+	return value, nil
+}
+
+func isComment(text string) bool {
+	text = strings.TrimSpace(text)
+	return text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";")
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+func TestParseCredentialsFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]map[string]string
+		errorMsg string
+	}{
+		{
+			name: "ini",
+			content: `; Langfuse credentials
+[default]
+host = https://cloud.langfuse.com
+admin_api_key = admin-key ; trailing comment
+
+[staging]
+project_public_key=pk-lf-staging
+project_secret_key = sk-lf-staging
+`,
+			expected: map[string]map[string]string{
+				"default": {"host": "https://cloud.langfuse.com", "admin_api_key": "admin-key"},
+				"staging": {"project_public_key": "pk-lf-staging", "project_secret_key": "sk-lf-staging"},
+			},
+		},
+		{
+			name: "toml",
+			content: `# Langfuse credentials
+[default]
+host = "https://cloud.langfuse.com" # EU
+organization_public_key = 'pk-lf-org'
+organization_private_key = "sk-lf-\"org\""
+
+["us east"]
+host = "https://us.cloud.langfuse.com"
+`,
+			expected: map[string]map[string]string{
+				"default": {
+					"host":                     "https://cloud.langfuse.com",
+					"organization_public_key":  "pk-lf-org",
+					"organization_private_key": `sk-lf-"org"`,
+				},
+				"us east": {"host": "https://us.cloud.langfuse.com"},
+			},
+		},
+		{
+			name:     "empty",
+			content:  "",
+			expected: map[string]map[string]string{},
+		},
+		{
+			name:     "key_outside_profile",
+			content:  "host = https://cloud.langfuse.com\n",
+			errorMsg: "line 1: host is not in a profile",
+		},
+		{
+			name:     "invalid_header",
+			content:  "[default\n",
+			errorMsg: "line 1: invalid profile header",
+		},
+		{
+			name:     "duplicate_profile",
+			content:  "[default]\n[default]\n",
+			errorMsg: `line 2: duplicate profile "default"`,
+		},
+		{
+			name:     "duplicate_key",
+			content:  "[default]\nhost = a\nhost = b\n",
+			errorMsg: "line 3: duplicate key host",
+		},
+		{
+			name:     "missing_equals",
+			content:  "[default]\nhost\n",
+			errorMsg: "line 2: expected key = value",
+		},
+		{
+			name:     "unterminated_string",
+			content:  "[default]\nhost = \"https://cloud.langfuse.com\n",
+			errorMsg: "line 2: invalid value of host: missing closing quote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			profiles, err := parseCredentialsFile(strings.NewReader(tt.content))
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("expected an error containing %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(profiles, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, profiles)
+			}
+		})
+	}
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials")
+	content := `[default]
+host = https://cloud.langfuse.com
+admin_api_key = admin-key
+
+[project-only]
+project_public_key = pk-lf-project
+project_secret_key = sk-lf-project
+
+[half-organization]
+organization_public_key = pk-lf-org
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		profile  string
+		expected credentialsProfile
+		errorMsg string
+	}{
+		{
+			name:     "default_profile",
+			path:     path,
+			expected: credentialsProfile{Host: "https://cloud.langfuse.com", AdminAPIKey: "admin-key"},
+		},
+		{
+			name:     "selected_profile",
+			path:     path,
+			profile:  "project-only",
+			expected: credentialsProfile{Project: langfuse.Credentials{PublicKey: "pk-lf-project", SecretKey: "sk-lf-project"}},
+		},
+		{
+			name:     "unknown_profile",
+			path:     path,
+			profile:  "prod",
+			errorMsg: `profile "prod" not found`,
+		},
+		{
+			name:     "incomplete_keys",
+			path:     path,
+			profile:  "half-organization",
+			errorMsg: "must set organization_public_key and organization_private_key together",
+		},
+		{
+			name: "missing_file_without_profile",
+			path: filepath.Join(dir, "missing"),
+		},
+		{
+			name:     "missing_file_with_profile",
+			path:     filepath.Join(dir, "missing"),
+			profile:  "default",
+			errorMsg: `profile "default" not found in`,
+		},
+		{
+			name: "unknown_home_without_profile",
+			path: "",
+		},
+		{
+			name:     "unknown_home_with_profile",
+			path:     "",
+			profile:  "default",
+			errorMsg: "the home directory is unknown",
+		},
+		{
+			name:     "path_is_a_directory",
+			path:     dir,
+			errorMsg: "parsing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile, err := loadCredentialsProfile(tt.path, tt.profile)
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("expected an error containing %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if profile != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, profile)
+			}
+		})
+	}
+}
+
+func TestCredentialsFilePath(t *testing.T) {
+	t.Setenv("LANGFUSE_CREDENTIALS_FILE", "")
+	t.Setenv("HOME", "/home/langfuse")
+	if path := credentialsFilePath(); path != filepath.Join("/home/langfuse", ".config", "langfuse", "credentials") {
+		t.Fatalf("credentialsFilePath() = %q", path)
+	}
+	if credentialsFileSelected("") {
+		t.Fatalf("the credentials file must not be read without a profile or LANGFUSE_CREDENTIALS_FILE")
+	}
+	if !credentialsFileSelected("prod") {
+		t.Fatalf("the credentials file must be read for a named profile")
+	}
+
+	t.Setenv("HOME", "")
+	if path := credentialsFilePath(); path != "" {
+		t.Fatalf("credentialsFilePath() = %q without a home directory", path)
+	}
+
+	t.Setenv("LANGFUSE_CREDENTIALS_FILE", "/etc/langfuse/credentials")
+	if path := credentialsFilePath(); path != "/etc/langfuse/credentials" {
+		t.Fatalf("credentialsFilePath() = %q", path)
+	}
+	if !credentialsFileSelected("") {
+		t.Fatalf("the credentials file must be read when LANGFUSE_CREDENTIALS_FILE is set")
+	}
+}
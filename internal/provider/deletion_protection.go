@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+// planDeletionProtection plans the default deletion protection of the provider for resources that do not
+// set deletion_protection.
+func planDeletionProtection(ctx context.Context, clientFactory langfuse.ClientFactory, config tfsdk.Config, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Raw.IsNull() {
+		return diags
+	}
+
+	var deletionProtection types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if diags.HasError() || !deletionProtection.IsNull() {
+		return diags
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("deletion_protection"), defaultDeletionProtection(clientFactory))...)
+	return diags
+}
+
+// deletionProtectionOrDefault returns the deletion protection set by a resource, or the default of the
+// provider when it sets none, which is also what planDeletionProtection plans.
+func deletionProtectionOrDefault(deletionProtection types.Bool, clientFactory langfuse.ClientFactory) types.Bool {
+	if deletionProtection.IsNull() || deletionProtection.IsUnknown() {
+		return defaultDeletionProtection(clientFactory)
+	}
+	return deletionProtection
+}
+
+// defaultDeletionProtection returns the default deletion protection of the provider, or false if the
+// resource has not been configured.
+func defaultDeletionProtection(clientFactory langfuse.ClientFactory) types.Bool {
+	return types.BoolValue(clientFactory != nil && clientFactory.DefaultDeletionProtection())
+}
+
+// checkDeletionProtection refuses to delete a resource whose state has deletion protection. Since the state
+// is the one of the previous apply, turning the protection off takes an apply of its own.
+func checkDeletionProtection(deletionProtection types.Bool, resourceType, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if deletionProtection.ValueBool() {
+		diags.AddAttributeError(path.Root("deletion_protection"), "Deletion protection enabled",
+			fmt.Sprintf("%s %s has deletion_protection enabled and cannot be deleted. Apply deletion_protection = false first, then delete it.", resourceType, id))
+	}
+	return diags
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	planSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"deletion_protection": schema.BoolAttribute{Optional: true, Computed: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"deletion_protection": tftypes.Bool}}
+
+	tests := []struct {
+		name              string
+		configured        any
+		providerDefault   bool
+		expectedProtected types.Bool
+	}{
+		{name: "provider_default", configured: nil, providerDefault: true, expectedProtected: types.BoolValue(true)},
+		{name: "unprotected_by_default", configured: nil, expectedProtected: types.BoolValue(false)},
+		{name: "configured", configured: false, providerDefault: true, expectedProtected: types.BoolValue(false)},
+		{name: "unknown", configured: tftypes.UnknownValue, providerDefault: true, expectedProtected: types.BoolUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientFactory := mocks.NewMockClientFactory(gomock.NewController(t))
+			clientFactory.DeletionProtection = tt.providerDefault
+
+			raw := tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, tt.configured),
+			})
+			config := tfsdk.Config{Schema: planSchema, Raw: raw}
+			plan := tfsdk.Plan{Schema: planSchema, Raw: raw}
+			if diags := planDeletionProtection(ctx, clientFactory, config, &plan); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var deletionProtection types.Bool
+			plan.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)
+			if !deletionProtection.Equal(tt.expectedProtected) {
+				t.Fatalf("expected deletion_protection %v, got %v", tt.expectedProtected, deletionProtection)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	if diags := checkDeletionProtection(types.BoolValue(true), "langfuse_project", "proj-123"); !diags.HasError() {
+		t.Fatalf("expected protected resources not to be deleted")
+	}
+	for _, deletionProtection := range []types.Bool{types.BoolValue(false), types.BoolNull()} {
+		if diags := checkDeletionProtection(deletionProtection, "langfuse_project", "proj-123"); diags.HasError() {
+			t.Fatalf("unexpected diagnostics for deletion_protection %v: %v", deletionProtection, diags)
+		}
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
@@ -20,29 +23,28 @@ import (
 )
 
 var _ resource.Resource = &llmConnectionsResource{}
+var _ resource.ResourceWithModifyPlan = &llmConnectionsResource{}
 var _ resource.ResourceWithConfigValidators = &llmConnectionsResource{}
 var _ resource.ResourceWithImportState = &llmConnectionsResource{}
 
-// llmConnectionsPageSize is the number of results to request per page when listing
-// LLM connections. 100 is the practical maximum to minimise round-trips.
-const llmConnectionsPageSize = 100
-
 func NewLlmConnectionResource() resource.Resource {
 	return &llmConnectionsResource{}
 }
 
 type llmConnectionsResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	ProjectPublicKey  types.String `tfsdk:"project_public_key"`
-	ProjectSecretKey  types.String `tfsdk:"project_secret_key"`
-	ProviderName      types.String `tfsdk:"provider_name"`
-	Adapter           types.String `tfsdk:"adapter"`
-	SecretKey         types.String `tfsdk:"secret_key"`
-	BaseURL           types.String `tfsdk:"base_url"`
-	CustomModels      types.List   `tfsdk:"custom_models"`
-	ExtraHeaders      types.Map    `tfsdk:"extra_headers"`
-	WithDefaultModels types.Bool   `tfsdk:"with_default_models"`
-	Config            types.String `tfsdk:"config"`
+	ID                types.String   `tfsdk:"id"`
+	ProjectPublicKey  types.String   `tfsdk:"project_public_key"`
+	ProjectSecretKey  types.String   `tfsdk:"project_secret_key"`
+	Credentials       types.String   `tfsdk:"credentials"`
+	ProviderName      types.String   `tfsdk:"provider_name"`
+	Adapter           types.String   `tfsdk:"adapter"`
+	SecretKey         types.String   `tfsdk:"secret_key"`
+	BaseURL           types.String   `tfsdk:"base_url"`
+	CustomModels      types.List     `tfsdk:"custom_models"`
+	ExtraHeaders      types.Map      `tfsdk:"extra_headers"`
+	WithDefaultModels types.Bool     `tfsdk:"with_default_models"`
+	Config            types.String   `tfsdk:"config"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 type llmConnectionsResource struct {
@@ -70,6 +72,10 @@ func (r *llmConnectionsResource) Metadata(ctx context.Context, req resource.Meta
 	resp.TypeName = req.ProviderTypeName + "_llm_connection"
 }
 
+func (r *llmConnectionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkProjectCredentials(ctx, r.ClientFactory, req.Plan, "langfuse_llm_connection")...)
+}
+
 func (r *llmConnectionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages an LLM connection in a Langfuse project.",
@@ -82,14 +88,22 @@ func (r *llmConnectionsResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"project_public_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The project public key used to authenticate API calls.",
+				Description: "The project public key used to authenticate API calls. Falls back to the project block of the provider, then to the LANGFUSE_PUBLIC_KEY environment variable.",
 			},
 			"project_secret_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The project secret key used to authenticate API calls.",
+				Description: "The project secret key used to authenticate API calls. Falls back to the project block of the provider, then to the LANGFUSE_SECRET_KEY environment variable.",
+			},
+			"credentials": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a credential set of the provider whose project keys authenticate the calls. Conflicts with project_public_key and project_secret_key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("project_public_key"), path.MatchRoot("project_secret_key")),
+				},
 			},
 			"provider_name": schema.StringAttribute{
 				Required:    true,
@@ -141,6 +155,9 @@ func (r *llmConnectionsResource) Schema(ctx context.Context, req resource.Schema
 				Description: "Adapter-specific configuration as a JSON string.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
@@ -222,7 +239,7 @@ func (r *llmConnectionsResource) ConfigValidators(ctx context.Context) []resourc
 
 // mapResponseToState maps an LlmConnection API response to the resource model,
 // preserving write-only fields (secretKey, extraHeaders) from the provided prior state.
-func mapResponseToState(conn *langfuse.LlmConnection, secretKey types.String, extraHeaders types.Map, projectPublicKey, projectSecretKey types.String) (llmConnectionsResourceModel, error) {
+func mapResponseToState(conn *langfuse.LlmConnection, secretKey types.String, extraHeaders types.Map, credentials, projectPublicKey, projectSecretKey types.String) (llmConnectionsResourceModel, error) {
 	state := llmConnectionsResourceModel{
 		ID:                types.StringValue(conn.ID),
 		ProviderName:      types.StringValue(conn.Provider),
@@ -232,6 +249,7 @@ func mapResponseToState(conn *langfuse.LlmConnection, secretKey types.String, ex
 		ExtraHeaders:      extraHeaders,
 		ProjectPublicKey:  projectPublicKey,
 		ProjectSecretKey:  projectSecretKey,
+		Credentials:       credentials,
 	}
 
 	if conn.BaseURL != "" {
@@ -318,7 +336,15 @@ func (r *llmConnectionsResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	client := r.ClientFactory.NewLlmConnectionsClient(plan.ProjectPublicKey.ValueString(), plan.ProjectSecretKey.ValueString())
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.ClientFactory.NewLlmConnectionsClient(plan.Credentials.ValueString(), plan.ProjectPublicKey.ValueString(), plan.ProjectSecretKey.ValueString())
 
 	upsertReq, err := buildUpsertRequest(plan)
 	if err != nil {
@@ -332,11 +358,12 @@ func (r *llmConnectionsResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	state, err := mapResponseToState(conn, plan.SecretKey, plan.ExtraHeaders, plan.ProjectPublicKey, plan.ProjectSecretKey)
+	state, err := mapResponseToState(conn, plan.SecretKey, plan.ExtraHeaders, plan.Credentials, plan.ProjectPublicKey, plan.ProjectSecretKey)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping LLM connection response", err.Error())
 		return
 	}
+	state.Timeouts = plan.Timeouts
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -348,27 +375,26 @@ func (r *llmConnectionsResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	client := r.ClientFactory.NewLlmConnectionsClient(state.ProjectPublicKey.ValueString(), state.ProjectSecretKey.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client := r.ClientFactory.NewLlmConnectionsClient(state.Credentials.ValueString(), state.ProjectPublicKey.ValueString(), state.ProjectSecretKey.ValueString())
 
 	var found *langfuse.LlmConnection
-	page := 1
-	pageSize := llmConnectionsPageSize
-	for {
-		listResp, err := client.ListLlmConnections(ctx, &page, &pageSize)
+	for connection, err := range client.ListLlmConnections(ctx) {
 		if err != nil {
 			resp.Diagnostics.AddError("Error listing LLM connections", err.Error())
 			return
 		}
-		for i := range listResp.Data {
-			if listResp.Data[i].Provider == state.ProviderName.ValueString() {
-				found = &listResp.Data[i]
-				break
-			}
-		}
-		if found != nil || page >= listResp.Meta.TotalPages {
+		if connection.Provider == state.ProviderName.ValueString() {
+			found = &connection
 			break
 		}
-		page++
 	}
 
 	if found == nil {
@@ -376,11 +402,12 @@ func (r *llmConnectionsResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	newState, err := mapResponseToState(found, state.SecretKey, state.ExtraHeaders, state.ProjectPublicKey, state.ProjectSecretKey)
+	newState, err := mapResponseToState(found, state.SecretKey, state.ExtraHeaders, state.Credentials, state.ProjectPublicKey, state.ProjectSecretKey)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping LLM connection response", err.Error())
 		return
 	}
+	newState.Timeouts = state.Timeouts
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
@@ -392,7 +419,15 @@ func (r *llmConnectionsResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	client := r.ClientFactory.NewLlmConnectionsClient(plan.ProjectPublicKey.ValueString(), plan.ProjectSecretKey.ValueString())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.ClientFactory.NewLlmConnectionsClient(plan.Credentials.ValueString(), plan.ProjectPublicKey.ValueString(), plan.ProjectSecretKey.ValueString())
 
 	upsertReq, err := buildUpsertRequest(plan)
 	if err != nil {
@@ -406,11 +441,12 @@ func (r *llmConnectionsResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	state, err := mapResponseToState(conn, plan.SecretKey, plan.ExtraHeaders, plan.ProjectPublicKey, plan.ProjectSecretKey)
+	state, err := mapResponseToState(conn, plan.SecretKey, plan.ExtraHeaders, plan.Credentials, plan.ProjectPublicKey, plan.ProjectSecretKey)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping LLM connection response", err.Error())
 		return
 	}
+	state.Timeouts = plan.Timeouts
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -422,7 +458,15 @@ func (r *llmConnectionsResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	client := r.ClientFactory.NewLlmConnectionsClient(state.ProjectPublicKey.ValueString(), state.ProjectSecretKey.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := r.ClientFactory.NewLlmConnectionsClient(state.Credentials.ValueString(), state.ProjectPublicKey.ValueString(), state.ProjectSecretKey.ValueString())
 
 	if err := client.DeleteLlmConnection(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Error deleting LLM connection", err.Error())
@@ -433,39 +477,39 @@ func (r *llmConnectionsResource) Delete(ctx context.Context, req resource.Delete
 }
 
 // ImportState imports an existing LLM connection by its project credentials and connection ID.
-// The import ID format is: <project_public_key>:<project_secret_key>:<connection_id>
+// The import ID format is: <project_public_key>:<project_secret_key>:<connection_id>,
+// <credentials>:<connection_id> to use a credential set of the provider, or only <connection_id>
+// when the project credentials come from the provider.
 func (r *llmConnectionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	parts := strings.SplitN(req.ID, ":", 3)
-	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+	if slices.Contains(parts, "") {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID must be in the format: <project_public_key>:<project_secret_key>:<connection_id>",
+			"Import ID must be in the format: <project_public_key>:<project_secret_key>:<connection_id>, <credentials>:<connection_id>, or <connection_id> when the project credentials come from the provider",
 		)
 		return
 	}
-	projectPublicKey, projectSecretKey, connectionID := parts[0], parts[1], parts[2]
+	credentials, projectPublicKey, projectSecretKey := types.StringNull(), types.StringNull(), types.StringNull()
+	connectionID := parts[len(parts)-1]
+	switch len(parts) {
+	case 2:
+		credentials = types.StringValue(parts[0])
+	case 3:
+		projectPublicKey, projectSecretKey = types.StringValue(parts[0]), types.StringValue(parts[1])
+	}
 
-	client := r.ClientFactory.NewLlmConnectionsClient(projectPublicKey, projectSecretKey)
+	client := r.ClientFactory.NewLlmConnectionsClient(credentials.ValueString(), projectPublicKey.ValueString(), projectSecretKey.ValueString())
 
 	var found *langfuse.LlmConnection
-	page := 1
-	pageSize := llmConnectionsPageSize
-	for {
-		listResp, err := client.ListLlmConnections(ctx, &page, &pageSize)
+	for connection, err := range client.ListLlmConnections(ctx) {
 		if err != nil {
 			resp.Diagnostics.AddError("Error listing LLM connections during import", err.Error())
 			return
 		}
-		for i := range listResp.Data {
-			if listResp.Data[i].ID == connectionID {
-				found = &listResp.Data[i]
-				break
-			}
-		}
-		if found != nil || page >= listResp.Meta.TotalPages {
+		if connection.ID == connectionID {
+			found = &connection
 			break
 		}
-		page++
 	}
 
 	if found == nil {
@@ -480,13 +524,21 @@ func (r *llmConnectionsResource) ImportState(ctx context.Context, req resource.I
 		found,
 		types.StringNull(),
 		types.MapNull(types.StringType),
-		types.StringValue(projectPublicKey),
-		types.StringValue(projectSecretKey),
+		credentials,
+		projectPublicKey,
+		projectSecretKey,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping LLM connection response", err.Error())
 		return
 	}
 
+	importTimeouts, diags := importedTimeouts(ctx, resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = importTimeouts
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -3,9 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"iter"
 	"testing"
 
-	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
+	"go.uber.org/mock/gomock"
 )
 
 // buildLlmConnectionObjectValue builds a tftypes.Value for the LLM connection schema
@@ -24,6 +25,7 @@ func buildLlmConnectionObjectValue(values map[string]tftypes.Value) tftypes.Valu
 				"id":                  tftypes.String,
 				"project_public_key":  tftypes.String,
 				"project_secret_key":  tftypes.String,
+				"credentials":         tftypes.String,
 				"provider_name":       tftypes.String,
 				"adapter":             tftypes.String,
 				"secret_key":          tftypes.String,
@@ -32,6 +34,7 @@ func buildLlmConnectionObjectValue(values map[string]tftypes.Value) tftypes.Valu
 				"extra_headers":       tftypes.Map{ElementType: tftypes.String},
 				"with_default_models": tftypes.Bool,
 				"config":              tftypes.String,
+				"timeouts":            crudTimeoutsType,
 			},
 			OptionalAttributes: map[string]struct{}{
 				"id":                  {},
@@ -40,6 +43,7 @@ func buildLlmConnectionObjectValue(values map[string]tftypes.Value) tftypes.Valu
 				"extra_headers":       {},
 				"with_default_models": {},
 				"config":              {},
+				"timeouts":            {},
 			},
 		},
 		values,
@@ -55,6 +59,7 @@ func buildLlmConnectionStateValue(id, projectPublicKey, projectSecretKey, provid
 		"id":                  tftypes.NewValue(tftypes.String, id),
 		"project_public_key":  tftypes.NewValue(tftypes.String, projectPublicKey),
 		"project_secret_key":  tftypes.NewValue(tftypes.String, projectSecretKey),
+		"credentials":         tftypes.NewValue(tftypes.String, nil),
 		"provider_name":       tftypes.NewValue(tftypes.String, provider),
 		"adapter":             tftypes.NewValue(tftypes.String, adapter),
 		"secret_key":          tftypes.NewValue(tftypes.String, secretKey),
@@ -63,6 +68,7 @@ func buildLlmConnectionStateValue(id, projectPublicKey, projectSecretKey, provid
 		"extra_headers":       extraHeadersVal,
 		"with_default_models": tftypes.NewValue(tftypes.Bool, nil),
 		"config":              tftypes.NewValue(tftypes.String, nil),
+		"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 	})
 }
 
@@ -105,7 +111,7 @@ func TestLlmConnectionsResource_Create(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		UpsertLlmConnection(ctx, gomock.Any()).
+		UpsertLlmConnection(contextWithDeadline(), gomock.Any()).
 		Return(&langfuse.LlmConnection{
 			ID:                "conn-123",
 			Provider:          "openai-prod",
@@ -119,6 +125,7 @@ func TestLlmConnectionsResource_Create(t *testing.T) {
 			"id":                  tftypes.NewValue(tftypes.String, nil),
 			"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 			"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+			"credentials":         tftypes.NewValue(tftypes.String, nil),
 			"provider_name":       tftypes.NewValue(tftypes.String, "openai-prod"),
 			"adapter":             tftypes.NewValue(tftypes.String, "openai"),
 			"secret_key":          tftypes.NewValue(tftypes.String, "my-api-key"),
@@ -127,6 +134,7 @@ func TestLlmConnectionsResource_Create(t *testing.T) {
 			"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 			"with_default_models": tftypes.NewValue(tftypes.Bool, nil),
 			"config":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 		}),
 		Schema: resourceSchema,
 	}
@@ -173,17 +181,14 @@ func TestLlmConnectionsResource_Read_NotFound(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-		Return(&langfuse.ListLlmConnectionsResponse{
-			Data: []langfuse.LlmConnection{
-				{
-					ID:       "conn-999",
-					Provider: "other-provider",
-					Adapter:  "anthropic",
-				},
+		ListLlmConnections(contextWithDeadline()).
+		Return(seqOf([]langfuse.LlmConnection{
+			{
+				ID:       "conn-999",
+				Provider: "other-provider",
+				Adapter:  "anthropic",
 			},
-			Meta: langfuse.PaginationMeta{Page: 1, Limit: 100, TotalItems: 1, TotalPages: 1},
-		}, nil)
+		}))
 
 	priorState := tfsdk.State{
 		Raw:    buildLlmConnectionStateValue("openai-prod", "pk-test", "sk-test", "openai-prod", "openai", "my-api-key", nil),
@@ -217,7 +222,7 @@ func TestLlmConnectionsResource_Delete(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		DeleteLlmConnection(ctx, "conn-123").
+		DeleteLlmConnection(contextWithDeadline(), "conn-123").
 		Return(nil)
 
 	state := tfsdk.State{
@@ -322,6 +327,7 @@ func TestLlmConnectionsResource_ConfigValidator(t *testing.T) {
 				"id":                  tftypes.NewValue(tftypes.String, nil),
 				"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 				"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+				"credentials":         tftypes.NewValue(tftypes.String, nil),
 				"provider_name":       tftypes.NewValue(tftypes.String, "test-provider"),
 				"adapter":             tftypes.NewValue(tftypes.String, tt.adapter),
 				"secret_key":          tftypes.NewValue(tftypes.String, "my-api-key"),
@@ -330,6 +336,7 @@ func TestLlmConnectionsResource_ConfigValidator(t *testing.T) {
 				"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 				"with_default_models": tftypes.NewValue(tftypes.Bool, nil),
 				"config":              tt.config,
+				"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 			})
 
 			config := tfsdk.Config{
@@ -378,19 +385,16 @@ func TestLlmConnectionsResource_WriteOnlyFieldsPreserved(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-		Return(&langfuse.ListLlmConnectionsResponse{
-			Data: []langfuse.LlmConnection{
-				{
-					ID:                "conn-123",
-					Provider:          "openai-prod",
-					Adapter:           "openai",
-					DisplaySecretKey:  "sk-...xyz",
-					WithDefaultModels: true,
-				},
+		ListLlmConnections(contextWithDeadline()).
+		Return(seqOf([]langfuse.LlmConnection{
+			{
+				ID:                "conn-123",
+				Provider:          "openai-prod",
+				Adapter:           "openai",
+				DisplaySecretKey:  "sk-...xyz",
+				WithDefaultModels: true,
 			},
-			Meta: langfuse.PaginationMeta{Page: 1, Limit: 100, TotalItems: 1, TotalPages: 1},
-		}, nil)
+		}))
 
 	extraHeadersMap := map[string]tftypes.Value{
 		"X-Custom": tftypes.NewValue(tftypes.String, "value"),
@@ -449,7 +453,7 @@ func TestLlmConnectionsResource_Update(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		UpsertLlmConnection(ctx, gomock.Any()).
+		UpsertLlmConnection(contextWithDeadline(), gomock.Any()).
 		Return(&langfuse.LlmConnection{
 			ID:                "conn-123",
 			Provider:          "openai-prod",
@@ -463,6 +467,7 @@ func TestLlmConnectionsResource_Update(t *testing.T) {
 			"id":                  tftypes.NewValue(tftypes.String, "openai-prod"),
 			"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 			"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+			"credentials":         tftypes.NewValue(tftypes.String, nil),
 			"provider_name":       tftypes.NewValue(tftypes.String, "openai-prod"),
 			"adapter":             tftypes.NewValue(tftypes.String, "openai"),
 			"secret_key":          tftypes.NewValue(tftypes.String, "updated-api-key"),
@@ -471,6 +476,7 @@ func TestLlmConnectionsResource_Update(t *testing.T) {
 			"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 			"with_default_models": tftypes.NewValue(tftypes.Bool, false),
 			"config":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 		}),
 		Schema: resourceSchema,
 	}
@@ -532,7 +538,7 @@ func TestLlmConnectionsResource_Create_WithOptionalFields(t *testing.T) {
 	}
 
 	llmClient.EXPECT().
-		UpsertLlmConnection(ctx, gomock.Any()).
+		UpsertLlmConnection(contextWithDeadline(), gomock.Any()).
 		Return(responseConn, nil)
 
 	customModelsList := tftypes.NewValue(
@@ -548,6 +554,7 @@ func TestLlmConnectionsResource_Create_WithOptionalFields(t *testing.T) {
 			"id":                  tftypes.NewValue(tftypes.String, nil),
 			"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 			"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+			"credentials":         tftypes.NewValue(tftypes.String, nil),
 			"provider_name":       tftypes.NewValue(tftypes.String, "bedrock-prod"),
 			"adapter":             tftypes.NewValue(tftypes.String, "bedrock"),
 			"secret_key":          tftypes.NewValue(tftypes.String, "my-aws-key"),
@@ -556,6 +563,7 @@ func TestLlmConnectionsResource_Create_WithOptionalFields(t *testing.T) {
 			"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 			"with_default_models": tftypes.NewValue(tftypes.Bool, true),
 			"config":              tftypes.NewValue(tftypes.String, `{"region":"us-east-1"}`),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 		}),
 		Schema: resourceSchema,
 	}
@@ -602,7 +610,7 @@ func TestLlmConnectionsResource_Create_Error(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		UpsertLlmConnection(ctx, gomock.Any()).
+		UpsertLlmConnection(contextWithDeadline(), gomock.Any()).
 		Return(nil, fmt.Errorf("upstream API error"))
 
 	createPlan := tfsdk.Plan{
@@ -610,6 +618,7 @@ func TestLlmConnectionsResource_Create_Error(t *testing.T) {
 			"id":                  tftypes.NewValue(tftypes.String, nil),
 			"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 			"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+			"credentials":         tftypes.NewValue(tftypes.String, nil),
 			"provider_name":       tftypes.NewValue(tftypes.String, "openai-prod"),
 			"adapter":             tftypes.NewValue(tftypes.String, "openai"),
 			"secret_key":          tftypes.NewValue(tftypes.String, "my-api-key"),
@@ -618,6 +627,7 @@ func TestLlmConnectionsResource_Create_Error(t *testing.T) {
 			"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 			"with_default_models": tftypes.NewValue(tftypes.Bool, nil),
 			"config":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 		}),
 		Schema: resourceSchema,
 	}
@@ -644,8 +654,8 @@ func TestLlmConnectionsResource_Read_Error(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-		Return(nil, fmt.Errorf("network error"))
+		ListLlmConnections(contextWithDeadline()).
+		Return(seqError[langfuse.LlmConnection](fmt.Errorf("network error")))
 
 	priorState := tfsdk.State{
 		Raw:    buildLlmConnectionStateValue("openai-prod", "pk-test", "sk-test", "openai-prod", "openai", "my-api-key", nil),
@@ -675,7 +685,7 @@ func TestLlmConnectionsResource_Update_Error(t *testing.T) {
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 	llmClient.EXPECT().
-		UpsertLlmConnection(ctx, gomock.Any()).
+		UpsertLlmConnection(contextWithDeadline(), gomock.Any()).
 		Return(nil, fmt.Errorf("upstream API error"))
 
 	updatePlan := tfsdk.Plan{
@@ -683,6 +693,7 @@ func TestLlmConnectionsResource_Update_Error(t *testing.T) {
 			"id":                  tftypes.NewValue(tftypes.String, "openai-prod"),
 			"project_public_key":  tftypes.NewValue(tftypes.String, "pk-test"),
 			"project_secret_key":  tftypes.NewValue(tftypes.String, "sk-test"),
+			"credentials":         tftypes.NewValue(tftypes.String, nil),
 			"provider_name":       tftypes.NewValue(tftypes.String, "openai-prod"),
 			"adapter":             tftypes.NewValue(tftypes.String, "openai"),
 			"secret_key":          tftypes.NewValue(tftypes.String, "updated-api-key"),
@@ -691,6 +702,7 @@ func TestLlmConnectionsResource_Update_Error(t *testing.T) {
 			"extra_headers":       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
 			"with_default_models": tftypes.NewValue(tftypes.Bool, nil),
 			"config":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 		}),
 		Schema: resourceSchema,
 	}
@@ -721,21 +733,30 @@ func TestLlmConnectionsResource_Read_Pagination(t *testing.T) {
 	ctx := context.Background()
 	r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
+	// The resource iterates over every page, which fetches them one by one.
+	llmClient.EXPECT().
+		ListLlmConnections(contextWithDeadline()).
+		DoAndReturn(func(ctx context.Context) iter.Seq2[langfuse.LlmConnection, error] {
+			return langfuse.Paginate(ctx, func(ctx context.Context, page int) (*langfuse.Page[langfuse.LlmConnection], error) {
+				return llmClient.ListLlmConnectionsPage(ctx, page, langfuse.DefaultPageSize)
+			})
+		})
+
 	// Page 1: does not contain the target provider
 	gomock.InOrder(
 		llmClient.EXPECT().
-			ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-			Return(&langfuse.ListLlmConnectionsResponse{
-				Data: []langfuse.LlmConnection{
+			ListLlmConnectionsPage(contextWithDeadline(), 1, langfuse.DefaultPageSize).
+			Return(&langfuse.Page[langfuse.LlmConnection]{
+				Items: []langfuse.LlmConnection{
 					{ID: "conn-1", Provider: "other-1", Adapter: "openai"},
 				},
 				Meta: langfuse.PaginationMeta{Page: 1, Limit: 100, TotalItems: 2, TotalPages: 2},
 			}, nil),
 		// Page 2: contains the target provider
 		llmClient.EXPECT().
-			ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-			Return(&langfuse.ListLlmConnectionsResponse{
-				Data: []langfuse.LlmConnection{
+			ListLlmConnectionsPage(contextWithDeadline(), 2, langfuse.DefaultPageSize).
+			Return(&langfuse.Page[langfuse.LlmConnection]{
+				Items: []langfuse.LlmConnection{
 					{
 						ID:                "conn-2",
 						Provider:          "openai-prod",
@@ -830,18 +851,15 @@ func TestLlmConnectionsResource_ImportState(t *testing.T) {
 		r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 		llmClient.EXPECT().
-			ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-			Return(&langfuse.ListLlmConnectionsResponse{
-				Data: []langfuse.LlmConnection{
-					{
-						ID:                "conn-123",
-						Provider:          "openai-prod",
-						Adapter:           "openai",
-						WithDefaultModels: true,
-					},
+			ListLlmConnections(ctx).
+			Return(seqOf([]langfuse.LlmConnection{
+				{
+					ID:                "conn-123",
+					Provider:          "openai-prod",
+					Adapter:           "openai",
+					WithDefaultModels: true,
 				},
-				Meta: langfuse.PaginationMeta{Page: 1, Limit: 100, TotalItems: 1, TotalPages: 1},
-			}, nil)
+			}))
 
 		var importResp resource.ImportStateResponse
 		importResp.State.Schema = resourceSchema
@@ -881,6 +899,67 @@ func TestLlmConnectionsResource_ImportState(t *testing.T) {
 		}
 	})
 
+	t.Run("connection_id_only", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
+
+		llmClient.EXPECT().
+			ListLlmConnections(ctx).
+			Return(seqOf([]langfuse.LlmConnection{{ID: "conn-123", Provider: "openai-prod", Adapter: "openai"}}))
+
+		var importResp resource.ImportStateResponse
+		importResp.State.Schema = resourceSchema
+
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "conn-123"}, &importResp)
+
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from ImportState: %v", importResp.Diagnostics)
+		}
+
+		var model llmConnectionsResourceModel
+		if diags := importResp.State.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("unexpected diagnostics getting model from imported state: %v", diags)
+		}
+		// The project credentials come from the environment and stay out of state.
+		if !model.ProjectPublicKey.IsNull() || !model.ProjectSecretKey.IsNull() {
+			t.Errorf("expected project keys to be null, got %q and %q", model.ProjectPublicKey.ValueString(), model.ProjectSecretKey.ValueString())
+		}
+	})
+
+	t.Run("credential_set", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
+
+		llmClient.EXPECT().
+			ListLlmConnections(ctx).
+			Return(seqOf([]langfuse.LlmConnection{{ID: "conn-123", Provider: "openai-prod", Adapter: "openai"}}))
+
+		var importResp resource.ImportStateResponse
+		importResp.State.Schema = resourceSchema
+
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "team-a:conn-123"}, &importResp)
+
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from ImportState: %v", importResp.Diagnostics)
+		}
+
+		var model llmConnectionsResourceModel
+		if diags := importResp.State.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("unexpected diagnostics getting model from imported state: %v", diags)
+		}
+		if model.Credentials.ValueString() != "team-a" || !model.ProjectPublicKey.IsNull() {
+			t.Errorf("expected the credential set to be kept instead of keys, got %q and %q", model.Credentials.ValueString(), model.ProjectPublicKey.ValueString())
+		}
+	})
+
 	t.Run("invalid_import_id", func(t *testing.T) {
 		t.Parallel()
 
@@ -889,7 +968,7 @@ func TestLlmConnectionsResource_ImportState(t *testing.T) {
 
 		r, _, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
-		for _, id := range []string{"", "pk-only", "pk:sk"} {
+		for _, id := range []string{"", ":conn-123", "pk:sk:", "::conn-123"} {
 			var importResp resource.ImportStateResponse
 			importResp.State.Schema = resourceSchema
 
@@ -910,11 +989,8 @@ func TestLlmConnectionsResource_ImportState(t *testing.T) {
 		r, llmClient, resourceSchema := setupLlmConnectionResource(t, ctrl)
 
 		llmClient.EXPECT().
-			ListLlmConnections(ctx, gomock.Any(), gomock.Any()).
-			Return(&langfuse.ListLlmConnectionsResponse{
-				Data: []langfuse.LlmConnection{},
-				Meta: langfuse.PaginationMeta{Page: 1, Limit: 100, TotalItems: 0, TotalPages: 1},
-			}, nil)
+			ListLlmConnections(ctx).
+			Return(seqOf([]langfuse.LlmConnection{}))
 
 		var importResp resource.ImportStateResponse
 		importResp.State.Schema = resourceSchema
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+)
+
+// metadataElements reads a metadata attribute. It returns nil when the attribute is null or unknown.
+func metadataElements(ctx context.Context, value types.Map) (map[string]string, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var metadata map[string]string
+	diags := value.ElementsAs(ctx, &metadata, false)
+	return metadata, diags
+}
+
+// mergeMetadata returns the default metadata of the provider overridden by the metadata of a resource, which
+// is what the resource sends to the API.
+func mergeMetadata(defaults, metadata map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(metadata))
+	maps.Copy(merged, defaults)
+	maps.Copy(merged, metadata)
+	return merged
+}
+
+// metadataState splits the metadata returned by the API into the metadata and metadata_all attributes. Keys
+// holding their default value are left out of metadata unless they were already in previous, the metadata of
+// the configuration or of the prior state, so that defaults do not show up as differences.
+func metadataState(ctx context.Context, metadata, defaults map[string]string, previous types.Map) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	previousMetadata, d := metadataElements(ctx, previous)
+	diags.Append(d...)
+
+	own := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		_, configured := previousMetadata[key]
+		if defaultValue, ok := defaults[key]; configured || !ok || defaultValue != value {
+			own[key] = value
+		}
+	}
+
+	metadataMap := types.MapNull(types.StringType)
+	if len(own) > 0 {
+		metadataMap, d = types.MapValueFrom(ctx, types.StringType, own)
+		diags.Append(d...)
+	}
+	metadataAll, d := types.MapValueFrom(ctx, types.StringType, mergeMetadata(nil, metadata))
+	diags.Append(d...)
+
+	return metadataMap, metadataAll, diags
+}
+
+// planMetadataAll sets the metadata_all attribute of plan to the metadata the resource will send to the API,
+// so that a change of the default metadata of the provider is planned as an update.
+func planMetadataAll(ctx context.Context, clientFactory langfuse.ClientFactory, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Raw.IsNull() {
+		return diags
+	}
+
+	var metadata types.Map
+	diags.Append(plan.GetAttribute(ctx, path.Root("metadata"), &metadata)...)
+	if diags.HasError() {
+		return diags
+	}
+	if metadata.IsUnknown() {
+		diags.Append(plan.SetAttribute(ctx, path.Root("metadata_all"), types.MapUnknown(types.StringType))...)
+		return diags
+	}
+
+	elements, d := metadataElements(ctx, metadata)
+	diags.Append(d...)
+	metadataAll, d := types.MapValueFrom(ctx, types.StringType, mergeMetadata(defaultMetadata(clientFactory), elements))
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("metadata_all"), metadataAll)...)
+	return diags
+}
+
+// defaultMetadata returns the default metadata of the provider, if the resource has been configured.
+func defaultMetadata(clientFactory langfuse.ClientFactory) map[string]string {
+	if clientFactory == nil {
+		return nil
+	}
+	return clientFactory.DefaultMetadata()
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMetadataState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	defaults := map[string]string{"managed_by": "terraform", "owner": "platform"}
+
+	tests := []struct {
+		name             string
+		metadata         map[string]string
+		previous         types.Map
+		expectedMetadata map[string]string
+	}{
+		{
+			name:             "defaults_left_out",
+			metadata:         map[string]string{"managed_by": "terraform", "owner": "platform", "team": "ai"},
+			previous:         types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("ai")}),
+			expectedMetadata: map[string]string{"team": "ai"},
+		},
+		{
+			name:             "overridden_default_kept",
+			metadata:         map[string]string{"managed_by": "terraform", "owner": "data"},
+			previous:         types.MapNull(types.StringType),
+			expectedMetadata: map[string]string{"owner": "data"},
+		},
+		{
+			name:             "configured_default_kept",
+			metadata:         map[string]string{"managed_by": "terraform", "owner": "platform"},
+			previous:         types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("platform")}),
+			expectedMetadata: map[string]string{"owner": "platform"},
+		},
+		{
+			name:     "only_defaults",
+			metadata: map[string]string{"managed_by": "terraform", "owner": "platform"},
+			previous: types.MapNull(types.StringType),
+		},
+		{
+			name:     "no_metadata",
+			previous: types.MapNull(types.StringType),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata, metadataAll, diags := metadataState(ctx, tt.metadata, defaults, tt.previous)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if tt.expectedMetadata == nil {
+				if !metadata.IsNull() {
+					t.Fatalf("expected null metadata, got %v", metadata)
+				}
+			} else {
+				var actual map[string]string
+				metadata.ElementsAs(ctx, &actual, false)
+				if !reflect.DeepEqual(actual, tt.expectedMetadata) {
+					t.Fatalf("expected metadata %v, got %v", tt.expectedMetadata, actual)
+				}
+			}
+
+			var all map[string]string
+			metadataAll.ElementsAs(ctx, &all, false)
+			if len(all) != len(tt.metadata) || (len(all) > 0 && !reflect.DeepEqual(all, tt.metadata)) {
+				t.Fatalf("expected metadata_all %v, got %v", tt.metadata, all)
+			}
+		})
+	}
+}
+
+func TestPlanMetadataAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clientFactory := mocks.NewMockClientFactory(gomock.NewController(t))
+	clientFactory.Metadata = map[string]string{"managed_by": "terraform", "owner": "platform"}
+
+	planSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"metadata":     schema.MapAttribute{Optional: true, ElementType: types.StringType},
+			"metadata_all": schema.MapAttribute{Computed: true, ElementType: types.StringType},
+		},
+	}
+	mapType := tftypes.Map{ElementType: tftypes.String}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"metadata": mapType, "metadata_all": mapType}}
+
+	tests := []struct {
+		name     string
+		metadata tftypes.Value
+		expected types.Map
+	}{
+		{
+			name: "merged",
+			metadata: tftypes.NewValue(mapType, map[string]tftypes.Value{
+				"owner": tftypes.NewValue(tftypes.String, "data"),
+			}),
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"managed_by": types.StringValue("terraform"),
+				"owner":      types.StringValue("data"),
+			}),
+		},
+		{
+			name:     "defaults_only",
+			metadata: tftypes.NewValue(mapType, nil),
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"managed_by": types.StringValue("terraform"),
+				"owner":      types.StringValue("platform"),
+			}),
+		},
+		{
+			name:     "unknown",
+			metadata: tftypes.NewValue(mapType, tftypes.UnknownValue),
+			expected: types.MapUnknown(types.StringType),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := tfsdk.Plan{
+				Schema: planSchema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"metadata":     tt.metadata,
+					"metadata_all": tftypes.NewValue(mapType, tftypes.UnknownValue),
+				}),
+			}
+			if diags := planMetadataAll(ctx, clientFactory, &plan); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var metadataAll types.Map
+			plan.GetAttribute(ctx, path.Root("metadata_all"), &metadataAll)
+			if !metadataAll.Equal(tt.expected) {
+				t.Fatalf("expected metadata_all %v, got %v", tt.expected, metadataAll)
+			}
+		})
+	}
+}
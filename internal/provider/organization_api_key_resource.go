@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -12,20 +13,23 @@ import (
 )
 
 var _ resource.Resource = &organizationApiKeyResource{}
+var _ resource.ResourceWithModifyPlan = &organizationApiKeyResource{}
 
 func NewOrganizationApiKeyResource() resource.Resource {
 	return &organizationApiKeyResource{}
 }
 
 type organizationApiKeyResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	PublicKey      types.String `tfsdk:"public_key"`
-	SecretKey      types.String `tfsdk:"secret_key"`
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	PublicKey      types.String   `tfsdk:"public_key"`
+	SecretKey      types.String   `tfsdk:"secret_key"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 type organizationApiKeyResource struct {
-	AdminClient langfuse.AdminClient
+	AdminClient   langfuse.AdminClient
+	ClientFactory langfuse.ClientFactory
 }
 
 func (r *organizationApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -33,18 +37,30 @@ func (r *organizationApiKeyResource) Configure(ctx context.Context, req resource
 		return
 	}
 
-	r.AdminClient = req.ProviderData.(langfuse.ClientFactory).NewAdminClient()
+	r.ClientFactory = req.ProviderData.(langfuse.ClientFactory)
+	r.AdminClient = r.ClientFactory.NewAdminClient()
 }
 
 func (r *organizationApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_organization_api_key"
 }
 
+func (r *organizationApiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkAdminAPIKey(r.ClientFactory, req.Plan, "langfuse_organization_api_key")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkServerRequirement(r.ClientFactory, req.Plan, "langfuse_organization_api_key", langfuse.RequireAdminAPI)...)
+}
+
 func (r *organizationApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"organization_id": schema.StringAttribute{
 				Required:    true,
@@ -74,6 +90,9 @@ func (r *organizationApiKeyResource) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
 	}
 }
 
@@ -85,6 +104,14 @@ func (r *organizationApiKeyResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	orgKey, err := r.AdminClient.CreateOrganizationApiKey(ctx, data.OrganizationID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating organization API key", err.Error())
@@ -96,6 +123,7 @@ func (r *organizationApiKeyResource) Create(ctx context.Context, req resource.Cr
 		OrganizationID: types.StringValue(data.OrganizationID.ValueString()),
 		PublicKey:      types.StringValue(orgKey.PublicKey),
 		SecretKey:      types.StringValue(orgKey.SecretKey),
+		Timeouts:       data.Timeouts,
 	})...)
 }
 
@@ -107,17 +135,43 @@ func (r *organizationApiKeyResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	_, err := r.AdminClient.GetOrganizationApiKey(ctx, data.OrganizationID.ValueString(), data.ID.ValueString())
 	if err != nil {
-		resp.State.RemoveResource(ctx)
+		if langfuse.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading organization API key", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update only stores the planned timeouts: the key itself is immutable, and a change of its organization
+// replaces it.
 func (r *organizationApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No update
+	var data, state organizationApiKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key values are only returned at creation time.
+	data.ID = state.ID
+	data.PublicKey = state.PublicKey
+	data.SecretKey = state.SecretKey
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *organizationApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -128,11 +182,19 @@ func (r *organizationApiKeyResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.AdminClient.DeleteOrganizationApiKey(ctx, data.OrganizationID.ValueString(), data.ID.ValueString())
-	if err != nil {
+	if err != nil && !langfuse.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error deleting organization API key", err.Error())
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationApiKeyResourceModel{})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationApiKeyResourceModel{Timeouts: data.Timeouts})...)
 }
@@ -3,8 +3,9 @@ package provider
 import (
 	"context"
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
@@ -92,13 +93,14 @@ func TestOrganizationApiKeyResourceCRUD(t *testing.T) {
 
 	var createResp resource.CreateResponse
 	t.Run("Create", func(t *testing.T) {
-		clientFactory.AdminClient.EXPECT().CreateOrganizationApiKey(ctx, orgID).Return(&langfuse.OrganizationApiKey{ID: "oak-123", PublicKey: "pk-1234", SecretKey: "sk-1234"}, nil)
+		clientFactory.AdminClient.EXPECT().CreateOrganizationApiKey(contextWithDeadline(), orgID).Return(&langfuse.OrganizationApiKey{ID: "oak-123", PublicKey: "pk-1234", SecretKey: "sk-1234"}, nil)
 
 		createConfig := tfsdk.Config{Raw: buildOrgApiKeyObjectValue(map[string]tftypes.Value{
 			"id":              tftypes.NewValue(tftypes.String, nil),
 			"organization_id": tftypes.NewValue(tftypes.String, orgID),
 			"public_key":      tftypes.NewValue(tftypes.String, nil),
 			"secret_key":      tftypes.NewValue(tftypes.String, nil),
+			"timeouts":        tftypes.NewValue(createReadDeleteTimeoutsType, nil),
 		}), Schema: resourceSchema}
 		createResp.State.Schema = resourceSchema
 		r.Create(ctx, resource.CreateRequest{Config: createConfig}, &createResp)
@@ -109,7 +111,7 @@ func TestOrganizationApiKeyResourceCRUD(t *testing.T) {
 
 	var readResp resource.ReadResponse
 	t.Run("Read", func(t *testing.T) {
-		clientFactory.AdminClient.EXPECT().GetOrganizationApiKey(ctx, orgID, "oak-123").Return(&langfuse.OrganizationApiKey{ID: "oak-123", PublicKey: "pk-1234", SecretKey: "sk-1234"}, nil)
+		clientFactory.AdminClient.EXPECT().GetOrganizationApiKey(contextWithDeadline(), orgID, "oak-123").Return(&langfuse.OrganizationApiKey{ID: "oak-123", PublicKey: "pk-1234", SecretKey: "sk-1234"}, nil)
 
 		readResp.State.Schema = resourceSchema
 		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
@@ -118,8 +120,40 @@ func TestOrganizationApiKeyResourceCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("Update stores the planned timeouts", func(t *testing.T) {
+		plan := tfsdk.Plan{Raw: buildOrgApiKeyObjectValue(map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"organization_id": tftypes.NewValue(tftypes.String, orgID),
+			"public_key":      tftypes.NewValue(tftypes.String, "pk-1234"),
+			"secret_key":      tftypes.NewValue(tftypes.String, "sk-1234"),
+			"timeouts": tftypes.NewValue(createReadDeleteTimeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"read":   tftypes.NewValue(tftypes.String, "10m"),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}), Schema: resourceSchema}
+
+		var updateResp resource.UpdateResponse
+		updateResp.State = readResp.State
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+		if updateResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from Update: %v", updateResp.Diagnostics)
+		}
+
+		var got organizationApiKeyResourceModel
+		if diags := updateResp.State.Get(ctx, &got); diags.HasError() {
+			t.Fatalf("unexpected diagnostics reading the state: %v", diags)
+		}
+		if got.ID.ValueString() != "oak-123" || got.SecretKey.ValueString() != "sk-1234" {
+			t.Fatalf("expected the key to be kept, got %q", got.ID.ValueString())
+		}
+		if readTimeout, _ := got.Timeouts.Read(ctx, 0); readTimeout != 10*time.Minute {
+			t.Fatalf("expected the planned read timeout, got %s", readTimeout)
+		}
+	})
+
 	t.Run("Delete", func(t *testing.T) {
-		clientFactory.AdminClient.EXPECT().DeleteOrganizationApiKey(ctx, orgID, "oak-123").Return(nil)
+		clientFactory.AdminClient.EXPECT().DeleteOrganizationApiKey(contextWithDeadline(), orgID, "oak-123").Return(nil)
 
 		var deleteResp resource.DeleteResponse
 		deleteResp.State.Schema = resourceSchema
@@ -138,11 +172,13 @@ func buildOrgApiKeyObjectValue(values map[string]tftypes.Value) tftypes.Value {
 				"organization_id": tftypes.String,
 				"public_key":      tftypes.String,
 				"secret_key":      tftypes.String,
+				"timeouts":        createReadDeleteTimeoutsType,
 			},
 			OptionalAttributes: map[string]struct{}{
 				"id":         {},
 				"public_key": {},
 				"secret_key": {},
+				"timeouts":   {},
 			},
 		},
 		values,
@@ -2,20 +2,23 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 )
 
 var _ resource.Resource = &organizationMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &organizationMembershipResource{}
 var _ resource.ResourceWithImportState = &organizationMembershipResource{}
 
 func NewOrganizationMembershipResource() resource.Resource {
@@ -23,14 +26,16 @@ func NewOrganizationMembershipResource() resource.Resource {
 }
 
 type organizationMembershipResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	Email                  types.String `tfsdk:"email"`
-	Role                   types.String `tfsdk:"role"`
-	Status                 types.String `tfsdk:"status"`
-	UserID                 types.String `tfsdk:"user_id"`
-	Username               types.String `tfsdk:"username"`
-	OrganizationPublicKey  types.String `tfsdk:"organization_public_key"`
-	OrganizationPrivateKey types.String `tfsdk:"organization_private_key"`
+	ID                     types.String   `tfsdk:"id"`
+	Email                  types.String   `tfsdk:"email"`
+	Role                   types.String   `tfsdk:"role"`
+	Status                 types.String   `tfsdk:"status"`
+	UserID                 types.String   `tfsdk:"user_id"`
+	Username               types.String   `tfsdk:"username"`
+	OrganizationPublicKey  types.String   `tfsdk:"organization_public_key"`
+	OrganizationPrivateKey types.String   `tfsdk:"organization_private_key"`
+	Credentials            types.String   `tfsdk:"credentials"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type organizationMembershipResource struct {
@@ -58,6 +63,54 @@ func (r *organizationMembershipResource) Metadata(ctx context.Context, req resou
 	resp.TypeName = req.ProviderTypeName + "_organization_membership"
 }
 
+func (r *organizationMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkOrganizationCredentials(ctx, r.ClientFactory, req.Plan, "langfuse_organization_membership")...)
+	if resp.Diagnostics.HasError() || r.ClientFactory == nil || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state organizationMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, r.organizationChanges(ctx, plan, state)...)
+}
+
+// organizationChanges returns the credential attributes to replace the membership for when they change to
+// keys of another organization. The keys are the only link of a membership to its organization, and the API
+// does not tell which organization a key belongs to, so the membership is looked up with the planned keys:
+// rotated keys of the same organization still find it and update it in place. Keys that are not known yet, or
+// that cannot be used while planning, are left to the apply, whose update fails if they do not find it.
+func (r *organizationMembershipResource) organizationChanges(ctx context.Context, plan, state organizationMembershipResourceModel) path.Paths {
+	var changed path.Paths
+	for _, attribute := range []struct {
+		path             path.Path
+		planned, current types.String
+	}{
+		{path.Root("organization_public_key"), plan.OrganizationPublicKey, state.OrganizationPublicKey},
+		{path.Root("organization_private_key"), plan.OrganizationPrivateKey, state.OrganizationPrivateKey},
+		{path.Root("credentials"), plan.Credentials, state.Credentials},
+	} {
+		if attribute.planned.IsUnknown() {
+			return nil
+		}
+		if !attribute.planned.Equal(attribute.current) {
+			changed = append(changed, attribute.path)
+		}
+	}
+	if len(changed) == 0 || state.ID.ValueString() == "" {
+		return nil
+	}
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(plan.Credentials.ValueString(), plan.OrganizationPublicKey.ValueString(), plan.OrganizationPrivateKey.ValueString())
+	if _, err := organizationClient.GetMembership(ctx, state.ID.ValueString()); langfuse.IsNotFound(err) {
+		return changed
+	}
+	return nil
+}
+
 func (r *organizationMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages membership in a Langfuse organization.",
@@ -93,22 +146,27 @@ func (r *organizationMembershipResource) Schema(ctx context.Context, req resourc
 				Computed:    true,
 			},
 			"organization_public_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization public key to authenticate the call.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "Organization public key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_PUBLIC_KEY environment variable.",
 			},
 			"organization_private_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization private key to authenticate the call.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				Description: "Organization private key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_SECRET_KEY environment variable.",
+			},
+			"credentials": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a credential set of the provider whose organization keys authenticate the calls. Conflicts with organization_public_key and organization_private_key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("organization_public_key"), path.MatchRoot("organization_private_key")),
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
@@ -119,6 +177,14 @@ func (r *organizationMembershipResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Validate role is one of the allowed values
 	validRoles := []string{"OWNER", "ADMIN", "MEMBER", "VIEWER", "NONE"}
 	role := plan.Role.ValueString()
@@ -137,21 +203,19 @@ func (r *organizationMembershipResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(plan.OrganizationPublicKey.ValueString(), plan.OrganizationPrivateKey.ValueString())
+	organizationClient := r.ClientFactory.NewOrganizationClient(plan.Credentials.ValueString(), plan.OrganizationPublicKey.ValueString(), plan.OrganizationPrivateKey.ValueString())
 
 	email := plan.Email.ValueString()
 
 	// Check if the user already exists in the organization
-	memberships, err := organizationClient.ListMemberships(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Error listing current memberships", err.Error())
-		return
-	}
-
 	var existingMembership *langfuse.OrganizationMembership
-	for i := range memberships {
-		if memberships[i].Email == email {
-			existingMembership = &memberships[i]
+	for membership, err := range organizationClient.ListMemberships(ctx) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing current memberships", err.Error())
+			return
+		}
+		if membership.Email == email {
+			existingMembership = &membership
 			break
 		}
 	}
@@ -182,16 +246,14 @@ func (r *organizationMembershipResource) Create(ctx context.Context, req resourc
 		}
 
 		// Refresh membership list to find the newly created user membership
-		memberships, err := organizationClient.ListMemberships(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Error listing memberships after SCIM user creation", err.Error())
-			return
-		}
-
 		var newMembership *langfuse.OrganizationMembership
-		for i := range memberships {
-			if memberships[i].UserID == scimUser.ID {
-				newMembership = &memberships[i]
+		for membership, err := range organizationClient.ListMemberships(ctx) {
+			if err != nil {
+				resp.Diagnostics.AddError("Error listing memberships after SCIM user creation", err.Error())
+				return
+			}
+			if membership.UserID == scimUser.ID {
+				newMembership = &membership
 				break
 			}
 		}
@@ -265,11 +327,19 @@ func (r *organizationMembershipResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(state.OrganizationPublicKey.ValueString(), state.OrganizationPrivateKey.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(state.Credentials.ValueString(), state.OrganizationPublicKey.ValueString(), state.OrganizationPrivateKey.ValueString())
 
 	membership, err := organizationClient.GetMembership(ctx, state.ID.ValueString())
 	if err != nil {
-		if errors.Is(err, langfuse.ErrMembershipNotFound) {
+		if langfuse.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -300,6 +370,14 @@ func (r *organizationMembershipResource) Update(ctx context.Context, req resourc
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var state organizationMembershipResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -324,13 +402,20 @@ func (r *organizationMembershipResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(state.OrganizationPublicKey.ValueString(), state.OrganizationPrivateKey.ValueString())
+	// The planned credentials authenticate the call: changing them to keys of the same organization only updates
+	// the membership in place, and the ones in the state may have been revoked.
+	organizationClient := r.ClientFactory.NewOrganizationClient(plan.Credentials.ValueString(), plan.OrganizationPublicKey.ValueString(), plan.OrganizationPrivateKey.ValueString())
 
 	updateRequest := &langfuse.UpdateMembershipRequest{
 		Role: role,
 	}
 
 	membership, err := organizationClient.UpdateMembership(ctx, state.ID.ValueString(), updateRequest)
+	if langfuse.IsNotFound(err) {
+		resp.Diagnostics.AddError("Membership not found with the planned organization credentials",
+			fmt.Sprintf("%s. The planned credentials may belong to another organization, whose membership the plan could not check; replace the resource, e.g. with terraform apply -replace, to move the user there.", err))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating membership", err.Error())
 		return
@@ -359,15 +444,34 @@ func (r *organizationMembershipResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(state.OrganizationPublicKey.ValueString(), state.OrganizationPrivateKey.ValueString())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(state.Credentials.ValueString(), state.OrganizationPublicKey.ValueString(), state.OrganizationPrivateKey.ValueString())
 
 	err := organizationClient.RemoveMember(ctx, state.UserID.ValueString())
-	if err != nil {
+	if err != nil && !langfuse.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error removing member", err.Error())
 		return
 	}
 }
 
 func (r *organizationMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Import format: membership_id[,credentials]
+	// Without a credential set, the organization credentials come from the provider.
+	membershipID, credentials, withCredentials := strings.Cut(req.ID, ",")
+	if membershipID == "" || (withCredentials && credentials == "") {
+		resp.Diagnostics.AddError("Invalid import format", "Import ID must be in format: membership_id[,credentials]")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), membershipID)...)
+	if withCredentials {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("credentials"), credentials)...)
+	}
 }
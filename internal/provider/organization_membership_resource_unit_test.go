@@ -4,9 +4,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/langfusetest"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
+	"go.uber.org/mock/gomock"
 )
 
 func TestOrganizationMembershipResourceMetadata(t *testing.T) {
@@ -46,7 +51,7 @@ func TestOrganizationMembershipResourceSchema(t *testing.T) {
 
 	expectedAttributes := []string{
 		"id", "email", "role", "status", "user_id", "username",
-		"organization_public_key", "organization_private_key",
+		"organization_public_key", "organization_private_key", "credentials",
 	}
 
 	for _, expectedAttr := range expectedAttributes {
@@ -78,6 +83,8 @@ func TestOrganizationMembershipResource_Create_InvalidRole(t *testing.T) {
 		"username":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
 		"organization_public_key":  tftypes.NewValue(tftypes.String, "test-public"),
 		"organization_private_key": tftypes.NewValue(tftypes.String, "test-private"),
+		"credentials":              tftypes.NewValue(tftypes.String, nil),
+		"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 	}
 
 	schemaResp := resource.SchemaResponse{}
@@ -124,6 +131,8 @@ func TestOrganizationMembershipResource_Update_InvalidRole(t *testing.T) {
 		"username":                 tftypes.NewValue(tftypes.String, "testuser"),
 		"organization_public_key":  tftypes.NewValue(tftypes.String, "test-public"),
 		"organization_private_key": tftypes.NewValue(tftypes.String, "test-private"),
+		"credentials":              tftypes.NewValue(tftypes.String, nil),
+		"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 	}
 
 	stateValue := map[string]tftypes.Value{
@@ -135,6 +144,8 @@ func TestOrganizationMembershipResource_Update_InvalidRole(t *testing.T) {
 		"username":                 tftypes.NewValue(tftypes.String, "testuser"),
 		"organization_public_key":  tftypes.NewValue(tftypes.String, "test-public"),
 		"organization_private_key": tftypes.NewValue(tftypes.String, "test-private"),
+		"credentials":              tftypes.NewValue(tftypes.String, nil),
+		"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 	}
 
 	schemaResp := resource.SchemaResponse{}
@@ -163,3 +174,236 @@ func TestOrganizationMembershipResource_Update_InvalidRole(t *testing.T) {
 		t.Fatalf("unexpected error summary. got %q, want %q", errorSummary, "Invalid Role")
 	}
 }
+
+func TestOrganizationMembershipResource_Update_PlannedCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientFactory := mocks.NewMockClientFactory(ctrl)
+	r := &organizationMembershipResource{ClientFactory: clientFactory}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	membershipValue := func(credentials, status, userID, username any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "membership-123"),
+			"email":                    tftypes.NewValue(tftypes.String, "test@example.com"),
+			"role":                     tftypes.NewValue(tftypes.String, "MEMBER"),
+			"status":                   tftypes.NewValue(tftypes.String, status),
+			"user_id":                  tftypes.NewValue(tftypes.String, userID),
+			"username":                 tftypes.NewValue(tftypes.String, username),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+			"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+			"credentials":              tftypes.NewValue(tftypes.String, credentials),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+		})
+	}
+
+	clientFactory.OrganizationClient.EXPECT().
+		UpdateMembership(contextWithDeadline(), "membership-123", &langfuse.UpdateMembershipRequest{Role: "MEMBER"}).
+		Return(&langfuse.OrganizationMembership{ID: "membership-123", Email: "test@example.com", Role: "MEMBER", Status: "ACTIVE", UserID: "user-123", Username: "testuser"}, nil)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: membershipValue("team-b", tftypes.UnknownValue, tftypes.UnknownValue, tftypes.UnknownValue)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: membershipValue("team-a", "ACTIVE", "user-123", "testuser")},
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Update: %v", resp.Diagnostics)
+	}
+	if got := clientFactory.OrganizationClientCredentials; got != (mocks.ClientCredentials{CredentialSet: "team-b"}) {
+		t.Fatalf("expected the planned credential set to authenticate the update, got %+v", got)
+	}
+
+	var state organizationMembershipResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if state.Credentials.ValueString() != "team-b" {
+		t.Fatalf("expected the planned credential set in the state, got %q", state.Credentials.ValueString())
+	}
+}
+
+func TestOrganizationMembershipResourceModifyPlanSkipsUnchangedAndUnknownCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	// The mock client expects no call: the membership is only looked up when known credentials change.
+	clientFactory := mocks.NewMockClientFactory(ctrl)
+	clientFactory.CredentialSets = map[string]langfuse.CredentialSet{
+		"team-a": {Organization: langfuse.Credentials{PublicKey: "pk-a", SecretKey: "sk-a"}},
+	}
+	r := &organizationMembershipResource{ClientFactory: clientFactory}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	membershipValue := func(credentials any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "membership-123"),
+			"email":                    tftypes.NewValue(tftypes.String, "test@example.com"),
+			"role":                     tftypes.NewValue(tftypes.String, "MEMBER"),
+			"status":                   tftypes.NewValue(tftypes.String, "ACTIVE"),
+			"user_id":                  tftypes.NewValue(tftypes.String, "user-123"),
+			"username":                 tftypes.NewValue(tftypes.String, "testuser"),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+			"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+			"credentials":              tftypes.NewValue(tftypes.String, credentials),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+		})
+	}
+
+	for name, planned := range map[string]any{"unchanged": "team-a", "unknown": tftypes.UnknownValue} {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: membershipValue(planned)}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: membershipValue("team-a")}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+		if resp.Diagnostics.HasError() || len(resp.RequiresReplace) != 0 {
+			t.Fatalf("%s: unexpected plan modification: %v, %v", name, resp.Diagnostics, resp.RequiresReplace)
+		}
+	}
+}
+
+// TestOrganizationMembershipResourceAgainstFakeServer runs the resource with real clients against the
+// in-memory Langfuse, covering the SCIM creation and the quirky removal response without mocks.
+func TestOrganizationMembershipResourceAgainstFakeServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := langfusetest.NewServer(t)
+	clientFactory := langfuse.NewClientFactory(server.URL, server.AdminKey)
+
+	org, err := clientFactory.NewAdminClient().CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{Name: "acme"})
+	if err != nil {
+		t.Fatalf("unexpected error creating organization: %v", err)
+	}
+	orgKey, err := clientFactory.NewAdminClient().CreateOrganizationApiKey(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("unexpected error creating organization API key: %v", err)
+	}
+
+	r := &organizationMembershipResource{ClientFactory: clientFactory}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"email":                    tftypes.NewValue(tftypes.String, "jane@example.com"),
+			"role":                     tftypes.NewValue(tftypes.String, "VIEWER"),
+			"status":                   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"user_id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"username":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, orgKey.PublicKey),
+			"organization_private_key": tftypes.NewValue(tftypes.String, orgKey.SecretKey),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+		}),
+	}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Create: %v", createResp.Diagnostics)
+	}
+
+	var created organizationMembershipResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Role.ValueString() != "VIEWER" || created.UserID.IsNull() || created.ID != created.UserID {
+		t.Fatalf("unexpected state after Create: %+v", created)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected Read to find the membership: %v", readResp.Diagnostics)
+	}
+
+	// Dropping the keys of the resource in favour of the rotated default key of the provider updates the
+	// membership in place, authenticated with the new key.
+	rotatedKey, err := clientFactory.NewAdminClient().CreateOrganizationApiKey(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("unexpected error creating organization API key: %v", err)
+	}
+	if err := clientFactory.NewAdminClient().DeleteOrganizationApiKey(ctx, org.ID, orgKey.ID); err != nil {
+		t.Fatalf("unexpected error deleting organization API key: %v", err)
+	}
+	r.ClientFactory = langfuse.NewClientFactory(server.URL, server.AdminKey,
+		langfuse.WithDefaultOrganizationCredentials(langfuse.Credentials{PublicKey: rotatedKey.PublicKey, SecretKey: rotatedKey.SecretKey}))
+
+	updatePlan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, created.ID.ValueString()),
+			"email":                    tftypes.NewValue(tftypes.String, "jane@example.com"),
+			"role":                     tftypes.NewValue(tftypes.String, "VIEWER"),
+			"status":                   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"user_id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"username":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+			"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+		}),
+	}
+	modifyResp := resource.ModifyPlanResponse{Plan: updatePlan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: updatePlan, State: createResp.State}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() || len(modifyResp.RequiresReplace) != 0 {
+		t.Fatalf("expected the rotated key to update the membership in place: %v, %v", modifyResp.Diagnostics, modifyResp.RequiresReplace)
+	}
+
+	// Keys of another organization do not find the membership, which is replaced instead.
+	otherOrg, err := clientFactory.NewAdminClient().CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{Name: "globex"})
+	if err != nil {
+		t.Fatalf("unexpected error creating organization: %v", err)
+	}
+	otherKey, err := clientFactory.NewAdminClient().CreateOrganizationApiKey(ctx, otherOrg.ID)
+	if err != nil {
+		t.Fatalf("unexpected error creating organization API key: %v", err)
+	}
+	other := &organizationMembershipResource{ClientFactory: langfuse.NewClientFactory(server.URL, server.AdminKey,
+		langfuse.WithDefaultOrganizationCredentials(langfuse.Credentials{PublicKey: otherKey.PublicKey, SecretKey: otherKey.SecretKey}))}
+
+	modifyResp = resource.ModifyPlanResponse{Plan: updatePlan}
+	other.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: updatePlan, State: createResp.State}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() || len(modifyResp.RequiresReplace) != 2 ||
+		!modifyResp.RequiresReplace.Contains(path.Root("organization_public_key")) || !modifyResp.RequiresReplace.Contains(path.Root("organization_private_key")) {
+		t.Fatalf("expected the keys of another organization to replace the membership: %v, %v", modifyResp.Diagnostics, modifyResp.RequiresReplace)
+	}
+
+	updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	other.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.ErrorsCount() != 1 || updateResp.Diagnostics.Errors()[0].Summary() != "Membership not found with the planned organization credentials" {
+		t.Fatalf("expected Update to refuse moving the membership to another organization, got %v", updateResp.Diagnostics)
+	}
+
+	updateResp = resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Update: %v", updateResp.Diagnostics)
+	}
+
+	var updated organizationMembershipResourceModel
+	updateResp.State.Get(ctx, &updated)
+	if !updated.OrganizationPublicKey.IsNull() || !updated.OrganizationPrivateKey.IsNull() || updated.ID != created.ID {
+		t.Fatalf("expected the planned keys in the state after Update: %+v", updated)
+	}
+
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Delete: %v", deleteResp.Diagnostics)
+	}
+
+	readResp = resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Fatalf("expected Read to drop the removed membership: %v", readResp.Diagnostics)
+	}
+}
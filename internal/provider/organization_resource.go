@@ -2,9 +2,8 @@ package provider
 
 import (
 	"context"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,6 +14,7 @@ import (
 )
 
 var _ resource.Resource = &organizationResource{}
+var _ resource.ResourceWithModifyPlan = &organizationResource{}
 var _ resource.ResourceWithImportState = &organizationResource{}
 
 func NewOrganizationResource() resource.Resource {
@@ -22,13 +22,17 @@ func NewOrganizationResource() resource.Resource {
 }
 
 type organizationResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Metadata types.Map    `tfsdk:"metadata"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Metadata           types.Map      `tfsdk:"metadata"`
+	MetadataAll        types.Map      `tfsdk:"metadata_all"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 type organizationResource struct {
-	AdminClient langfuse.AdminClient
+	AdminClient   langfuse.AdminClient
+	ClientFactory langfuse.ClientFactory
 }
 
 func (r *organizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -36,13 +40,24 @@ func (r *organizationResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	r.AdminClient = req.ProviderData.(langfuse.ClientFactory).NewAdminClient()
+	r.ClientFactory = req.ProviderData.(langfuse.ClientFactory)
+	r.AdminClient = r.ClientFactory.NewAdminClient()
 }
 
 func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_organization"
 }
 
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkAdminAPIKey(r.ClientFactory, req.Plan, "langfuse_organization")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkServerRequirement(r.ClientFactory, req.Plan, "langfuse_organization", langfuse.RequireAdminAPI)...)
+	resp.Diagnostics.Append(planMetadataAll(ctx, r.ClientFactory, &resp.Plan)...)
+	resp.Diagnostics.Append(planDeletionProtection(ctx, r.ClientFactory, req.Config, &resp.Plan)...)
+}
+
 func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -59,9 +74,22 @@ func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRe
 			"metadata": schema.MapAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
-				Description: "Metadata for the organization as key-value pairs.",
+				Description: "Metadata for the organization as key-value pairs. Merged over the default_metadata of the provider.",
+			},
+			"metadata_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Metadata of the organization, including the default_metadata of the provider.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Refuse to delete the organization, including when replacing it. Turning it off takes an apply of its own before the organization can be deleted. Defaults to the default_deletion_protection of the provider.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
@@ -73,6 +101,16 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
 	metadata := make(map[string]string)
 	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
 		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
@@ -80,6 +118,7 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 			return
 		}
 	}
+	metadata = mergeMetadata(defaultMetadata(r.ClientFactory), metadata)
 
 	org, err := r.AdminClient.CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{
 		Name:     data.Name.ValueString(),
@@ -90,22 +129,19 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	var metadataMap types.Map
-	if len(org.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, org.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, org.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationResourceModel{
-		ID:       types.StringValue(org.ID),
-		Name:     types.StringValue(org.Name),
-		Metadata: metadataMap,
+		ID:                 types.StringValue(org.ID),
+		Name:               types.StringValue(org.Name),
+		Metadata:           metadataMap,
+		MetadataAll:        metadataAll,
+		DeletionProtection: deletionProtection,
+		Timeouts:           data.Timeouts,
 	})...)
 }
 
@@ -117,28 +153,40 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	org, err := r.AdminClient.GetOrganization(ctx, data.ID.ValueString())
 	if err != nil {
+		if langfuse.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading organization", err.Error())
 		return
 	}
 
-	var metadataMap types.Map
-	if len(org.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, org.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	// The state may have been written before deletion_protection existed.
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
+	metadataMap, metadataAll, diags := metadataState(ctx, org.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationResourceModel{
-		ID:       types.StringValue(org.ID),
-		Name:     types.StringValue(org.Name),
-		Metadata: metadataMap,
+		ID:                 types.StringValue(org.ID),
+		Name:               types.StringValue(org.Name),
+		Metadata:           metadataMap,
+		MetadataAll:        metadataAll,
+		DeletionProtection: deletionProtection,
+		Timeouts:           data.Timeouts,
 	})...)
 }
 
@@ -150,6 +198,14 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get ID from current state (ID is not in config during updates)
 	var currentState organizationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
@@ -159,6 +215,8 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 
 	orgID := currentState.ID.ValueString()
 
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
 	metadata := make(map[string]string)
 	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
 		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
@@ -166,6 +224,7 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 	}
+	metadata = mergeMetadata(defaultMetadata(r.ClientFactory), metadata)
 
 	request := &langfuse.UpdateOrganizationRequest{
 		Name:     data.Name.ValueString(),
@@ -178,38 +237,44 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	var metadataMap types.Map
-	if len(org.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, org.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, org.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationResourceModel{
-		ID:       types.StringValue(org.ID),
-		Name:     types.StringValue(org.Name),
-		Metadata: metadataMap,
+		ID:                 types.StringValue(org.ID),
+		Name:               types.StringValue(org.Name),
+		Metadata:           metadataMap,
+		MetadataAll:        metadataAll,
+		DeletionProtection: deletionProtection,
+		Timeouts:           data.Timeouts,
 	})...)
 }
 
 func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data organizationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "langfuse_organization", data.ID.ValueString())...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.AdminClient.DeleteOrganization(ctx, data.ID.ValueString())
-	if err != nil {
-		// Handle the case where organization has existing projects
-		// This is common during test cleanup when dependencies aren't deleted in perfect order
-		if strings.Contains(err.Error(), "Cannot delete organization with existing projects") {
+	if err != nil && !langfuse.IsNotFound(err) {
+		// Handle the case where organization has existing projects, which Langfuse refuses with 400 Bad Request
+		// (or 409 Conflict). This is common during test cleanup when dependencies aren't deleted in perfect order
+		if langfuse.IsBadRequest(err) || langfuse.IsConflict(err) {
 			resp.Diagnostics.AddWarning(
 				"Organization deletion skipped",
 				"Organization still has existing projects. This is expected during test cleanup - "+
@@ -222,9 +287,11 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationResourceModel{
-		ID:       types.StringValue(""),
-		Name:     types.StringValue(""),
-		Metadata: types.MapNull(types.StringType),
+		ID:          types.StringValue(""),
+		Name:        types.StringValue(""),
+		Metadata:    types.MapNull(types.StringType),
+		MetadataAll: types.MapNull(types.StringType),
+		Timeouts:    data.Timeouts,
 	})...)
 }
 
@@ -240,24 +307,26 @@ func (r *organizationResource) ImportState(ctx context.Context, req resource.Imp
 		return
 	}
 
-	// Convert metadata to the appropriate type
-	var metadataMap types.Map
-	if len(org.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, org.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, org.Metadata, defaultMetadata(r.ClientFactory), types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importTimeouts, diags := importedTimeouts(ctx, resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set the imported state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &organizationResourceModel{
-		ID:       types.StringValue(org.ID),
-		Name:     types.StringValue(org.Name),
-		Metadata: metadataMap,
+		ID:                 types.StringValue(org.ID),
+		Name:               types.StringValue(org.Name),
+		Metadata:           metadataMap,
+		MetadataAll:        metadataAll,
+		DeletionProtection: defaultDeletionProtection(r.ClientFactory),
+		Timeouts:           importTimeouts,
 	})...)
 
 	// Set the ID attribute explicitly (this is a best practice for import)
@@ -2,10 +2,11 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"testing"
 
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
@@ -123,7 +124,7 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 	var createResp resource.CreateResponse
 	t.Run("Create", func(t *testing.T) {
 		clientFactory.AdminClient.EXPECT().
-			CreateOrganization(ctx, &langfuse.CreateOrganizationRequest{
+			CreateOrganization(contextWithDeadline(), &langfuse.CreateOrganizationRequest{
 				Name:     createName,
 				Metadata: createMetadata,
 			}).
@@ -140,9 +141,12 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 
 		createConfig := tfsdk.Config{
 			Raw: buildObjectValue(map[string]tftypes.Value{
-				"id":       tftypes.NewValue(tftypes.String, nil),
-				"name":     tftypes.NewValue(tftypes.String, createName),
-				"metadata": metadataValue,
+				"id":                  tftypes.NewValue(tftypes.String, nil),
+				"name":                tftypes.NewValue(tftypes.String, createName),
+				"metadata":            metadataValue,
+				"metadata_all":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+				"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 			}),
 			Schema: resourceSchema,
 		}
@@ -159,7 +163,7 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 	var readResp resource.ReadResponse
 	t.Run("Read", func(t *testing.T) {
 		clientFactory.AdminClient.EXPECT().
-			GetOrganization(ctx, "org-123").
+			GetOrganization(contextWithDeadline(), "org-123").
 			Return(&langfuse.Organization{
 				ID:       "org-123",
 				Name:     createName,
@@ -180,7 +184,7 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 		newName := "Acme Corporation"
 		newMetadata := map[string]string{"environment": "production", "team": "platform", "version": "2.0"}
 		clientFactory.AdminClient.EXPECT().
-			UpdateOrganization(ctx, "org-123", &langfuse.UpdateOrganizationRequest{
+			UpdateOrganization(contextWithDeadline(), "org-123", &langfuse.UpdateOrganizationRequest{
 				Name:     newName,
 				Metadata: newMetadata,
 			}).
@@ -198,9 +202,12 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 
 		updateConfig := tfsdk.Config{
 			Raw: buildObjectValue(map[string]tftypes.Value{
-				"id":       tftypes.NewValue(tftypes.String, "org-123"),
-				"name":     tftypes.NewValue(tftypes.String, newName),
-				"metadata": newMetadataValue,
+				"id":                  tftypes.NewValue(tftypes.String, "org-123"),
+				"name":                tftypes.NewValue(tftypes.String, newName),
+				"metadata":            newMetadataValue,
+				"metadata_all":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+				"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
 			}),
 			Schema: resourceSchema,
 		}
@@ -216,7 +223,7 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 
 	t.Run("Delete", func(t *testing.T) {
 		clientFactory.AdminClient.EXPECT().
-			DeleteOrganization(ctx, "org-123").
+			DeleteOrganization(contextWithDeadline(), "org-123").
 			Return(nil)
 
 		var deleteResp resource.DeleteResponse
@@ -290,15 +297,133 @@ func TestOrganizationResourceCRUD(t *testing.T) {
 }
 
 func buildObjectValue(values map[string]tftypes.Value) tftypes.Value {
-	return tftypes.NewValue(
-		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":       tftypes.String,
-				"name":     tftypes.String,
-				"metadata": tftypes.Map{ElementType: tftypes.String},
-			},
-			OptionalAttributes: map[string]struct{}{"id": {}, "metadata": {}},
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                  tftypes.String,
+			"name":                tftypes.String,
+			"metadata":            tftypes.Map{ElementType: tftypes.String},
+			"metadata_all":        tftypes.Map{ElementType: tftypes.String},
+			"deletion_protection": tftypes.Bool,
+			"timeouts":            crudTimeoutsType,
 		},
-		values,
-	)
+		OptionalAttributes: map[string]struct{}{"id": {}, "metadata": {}, "metadata_all": {}, "deletion_protection": {}, "timeouts": {}},
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestOrganizationResourceMergesDefaultMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	clientFactory := mocks.NewMockClientFactory(ctrl)
+	clientFactory.Metadata = map[string]string{"managed_by": "terraform", "team": "platform"}
+
+	r := NewOrganizationResource().(*organizationResource)
+	var configureResp resource.ConfigureResponse
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: clientFactory}, &configureResp)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	merged := map[string]string{"managed_by": "terraform", "team": "ai"}
+	clientFactory.AdminClient.EXPECT().
+		CreateOrganization(contextWithDeadline(), &langfuse.CreateOrganizationRequest{Name: "Acme Inc", Metadata: merged}).
+		Return(&langfuse.Organization{ID: "org-123", Name: "Acme Inc", Metadata: merged}, nil)
+
+	config := tfsdk.Config{
+		Raw: buildObjectValue(map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, nil),
+			"name": tftypes.NewValue(tftypes.String, "Acme Inc"),
+			"metadata": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"team": tftypes.NewValue(tftypes.String, "ai"),
+			}),
+			"metadata_all":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+			"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
+		}),
+		Schema: schemaResp.Schema,
+	}
+
+	var createResp resource.CreateResponse
+	createResp.State.Schema = schemaResp.Schema
+	r.Create(ctx, resource.CreateRequest{Config: config}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Create: %v", createResp.Diagnostics)
+	}
+
+	var state organizationResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &state)...)
+	var metadata, metadataAll map[string]string
+	createResp.Diagnostics.Append(state.Metadata.ElementsAs(ctx, &metadata, false)...)
+	createResp.Diagnostics.Append(state.MetadataAll.ElementsAs(ctx, &metadataAll, false)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics reading the state: %v", createResp.Diagnostics)
+	}
+	if !reflect.DeepEqual(metadata, map[string]string{"team": "ai"}) {
+		t.Fatalf("metadata should only hold the configured keys, got %v", metadata)
+	}
+	if !reflect.DeepEqual(metadataAll, merged) {
+		t.Fatalf("metadata_all should hold the merged metadata, got %v", metadataAll)
+	}
+}
+
+func TestOrganizationResourceDeleteWithProjects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantWarning bool
+	}{
+		{name: "bad request", err: &langfuse.APIError{StatusCode: http.StatusBadRequest, Message: "Cannot delete organization with existing projects"}, wantWarning: true},
+		{name: "conflict", err: &langfuse.APIError{StatusCode: http.StatusConflict}, wantWarning: true},
+		{name: "server error", err: &langfuse.APIError{StatusCode: http.StatusInternalServerError}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			clientFactory := mocks.NewMockClientFactory(ctrl)
+			clientFactory.AdminClient.EXPECT().DeleteOrganization(contextWithDeadline(), "org-123").Return(tt.err)
+
+			r := NewOrganizationResource().(*organizationResource)
+			var configureResp resource.ConfigureResponse
+			r.Configure(ctx, resource.ConfigureRequest{ProviderData: clientFactory}, &configureResp)
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			state := tfsdk.State{
+				Raw: buildObjectValue(map[string]tftypes.Value{
+					"id":                  tftypes.NewValue(tftypes.String, "org-123"),
+					"name":                tftypes.NewValue(tftypes.String, "Acme Inc"),
+					"metadata":            tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"metadata_all":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+					"timeouts":            tftypes.NewValue(crudTimeoutsType, nil),
+				}),
+				Schema: schemaResp.Schema,
+			}
+
+			var deleteResp resource.DeleteResponse
+			deleteResp.State.Schema = schemaResp.Schema
+			r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+
+			if tt.wantWarning {
+				if deleteResp.Diagnostics.HasError() || deleteResp.Diagnostics.WarningsCount() != 1 {
+					t.Fatalf("expected a single warning, got %v", deleteResp.Diagnostics)
+				}
+				return
+			}
+			if deleteResp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected an error, got %v", deleteResp.Diagnostics)
+			}
+		})
+	}
 }
@@ -3,28 +3,35 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 )
 
 var _ resource.Resource = &projectApiKeyResource{}
+var _ resource.ResourceWithModifyPlan = &projectApiKeyResource{}
 
 func NewProjectApiKeyResource() resource.Resource {
 	return &projectApiKeyResource{}
 }
 
 type projectApiKeyResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	OrganizationPublicKey  types.String `tfsdk:"organization_public_key"`
-	OrganizationPrivateKey types.String `tfsdk:"organization_private_key"`
-	ProjectID              types.String `tfsdk:"project_id"`
-	Note                   types.String `tfsdk:"note"`
-	PublicKey              types.String `tfsdk:"public_key"`
-	SecretKey              types.String `tfsdk:"secret_key"`
+	ID                     types.String   `tfsdk:"id"`
+	OrganizationPublicKey  types.String   `tfsdk:"organization_public_key"`
+	OrganizationPrivateKey types.String   `tfsdk:"organization_private_key"`
+	Credentials            types.String   `tfsdk:"credentials"`
+	ProjectID              types.String   `tfsdk:"project_id"`
+	Note                   types.String   `tfsdk:"note"`
+	PublicKey              types.String   `tfsdk:"public_key"`
+	SecretKey              types.String   `tfsdk:"secret_key"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type projectApiKeyResource struct {
@@ -43,11 +50,18 @@ func (r *projectApiKeyResource) Metadata(ctx context.Context, req resource.Metad
 	resp.TypeName = req.ProviderTypeName + "_project_api_key"
 }
 
+func (r *projectApiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkOrganizationCredentials(ctx, r.ClientFactory, req.Plan, "langfuse_project_api_key")...)
+}
+
 func (r *projectApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"project_id": schema.StringAttribute{
 				Required:    true,
@@ -57,20 +71,28 @@ func (r *projectApiKeyResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"organization_public_key": schema.StringAttribute{
-				Required:    true,
-				Description: "Organization public key to authenticate the call.",
+				Optional:    true,
+				Description: "Organization public key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_PUBLIC_KEY environment variable.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"organization_private_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization private key to authenticate the call.",
+				Description: "Organization private key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_SECRET_KEY environment variable.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"credentials": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a credential set of the provider whose organization keys authenticate the calls. Conflicts with organization_public_key and organization_private_key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("organization_public_key"), path.MatchRoot("organization_private_key")),
+				},
+			},
 			"note": schema.StringAttribute{
 				Optional: true,
 				Description: "Optional note for the API key (POST /api/public/projects/{projectId}/apiKeys). " +
@@ -98,6 +120,9 @@ func (r *projectApiKeyResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
 	}
 }
 
@@ -109,7 +134,15 @@ func (r *projectApiKeyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	createReq := planNoteToCreateRequest(data.Note)
 	projectApiKey, err := organizationClient.CreateProjectApiKey(ctx, data.ProjectID.ValueString(), createReq)
 	if err != nil {
@@ -119,12 +152,14 @@ func (r *projectApiKeyResource) Create(ctx context.Context, req resource.CreateR
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &projectApiKeyResourceModel{
 		ID:                     types.StringValue(projectApiKey.ID),
-		OrganizationPublicKey:  types.StringValue(data.OrganizationPublicKey.ValueString()),
-		OrganizationPrivateKey: types.StringValue(data.OrganizationPrivateKey.ValueString()),
+		OrganizationPublicKey:  data.OrganizationPublicKey,
+		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
 		ProjectID:              types.StringValue(data.ProjectID.ValueString()),
 		Note:                   projectApiKeyNoteToTF(projectApiKey.Note),
 		PublicKey:              types.StringValue(projectApiKey.PublicKey),
 		SecretKey:              types.StringValue(projectApiKey.SecretKey),
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
@@ -135,10 +170,22 @@ func (r *projectApiKeyResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	key, err := organizationClient.GetProjectApiKey(ctx, data.ProjectID.ValueString(), data.ID.ValueString())
 	if err != nil {
-		resp.State.RemoveResource(ctx)
+		if langfuse.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading project API key", err.Error())
 		return
 	}
 
@@ -147,8 +194,22 @@ func (r *projectApiKeyResource) Read(ctx context.Context, req resource.ReadReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update only stores the planned values: the key itself is immutable, and a change of its project or note
+// replaces it. What changes in place, such as the timeouts, only lives in the state.
 func (r *projectApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No updates are supported; keys are immutable. Any change should force recreation.
+	var data, state projectApiKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key values are only returned at creation time.
+	data.ID = state.ID
+	data.PublicKey = state.PublicKey
+	data.SecretKey = state.SecretKey
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *projectApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -159,14 +220,22 @@ func (r *projectApiKeyResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	err := organizationClient.DeleteProjectApiKey(ctx, data.ProjectID.ValueString(), data.ID.ValueString())
-	if err != nil {
+	if err != nil && !langfuse.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error deleting project API key", err.Error())
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &projectApiKeyResourceModel{})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &projectApiKeyResourceModel{Timeouts: data.Timeouts})...)
 }
 
 func projectApiKeyNoteToTF(note *string) types.String {
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
@@ -104,16 +106,18 @@ func TestProjectApiKeyResourceCRUD(t *testing.T) {
 
 	var createResp resource.CreateResponse
 	t.Run("Create", func(t *testing.T) {
-		clientFactory.OrganizationClient.EXPECT().CreateProjectApiKey(ctx, projectID, nil).Return(&langfuse.ProjectApiKey{ID: projectApiKeyID, PublicKey: publicKey, SecretKey: privateKey}, nil)
+		clientFactory.OrganizationClient.EXPECT().CreateProjectApiKey(contextWithDeadline(), projectID, nil).Return(&langfuse.ProjectApiKey{ID: projectApiKeyID, PublicKey: publicKey, SecretKey: privateKey}, nil)
 
 		createConfig := tfsdk.Config{Raw: buildApiKeyObjectValue(map[string]tftypes.Value{
 			"id":                       tftypes.NewValue(tftypes.String, nil),
 			"project_id":               tftypes.NewValue(tftypes.String, projectID),
 			"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
 			"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
 			"note":                     tftypes.NewValue(tftypes.String, nil),
 			"public_key":               tftypes.NewValue(tftypes.String, nil),
 			"secret_key":               tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(createReadDeleteTimeoutsType, nil),
 		}), Schema: resourceSchema}
 		createResp.State.Schema = resourceSchema
 
@@ -125,7 +129,7 @@ func TestProjectApiKeyResourceCRUD(t *testing.T) {
 
 	var readResp resource.ReadResponse
 	t.Run("Read", func(t *testing.T) {
-		clientFactory.OrganizationClient.EXPECT().GetProjectApiKey(ctx, projectID, projectApiKeyID).Return(&langfuse.ProjectApiKey{ID: projectApiKeyID, PublicKey: publicKey, SecretKey: privateKey}, nil)
+		clientFactory.OrganizationClient.EXPECT().GetProjectApiKey(contextWithDeadline(), projectID, projectApiKeyID).Return(&langfuse.ProjectApiKey{ID: projectApiKeyID, PublicKey: publicKey, SecretKey: privateKey}, nil)
 
 		readResp.State.Schema = resourceSchema
 		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
@@ -134,8 +138,101 @@ func TestProjectApiKeyResourceCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("Read keeps state on authentication error", func(t *testing.T) {
+		clientFactory.OrganizationClient.EXPECT().GetProjectApiKey(contextWithDeadline(), projectID, projectApiKeyID).Return(nil, &langfuse.APIError{StatusCode: http.StatusUnauthorized})
+
+		var resp resource.ReadResponse
+		resp.State = readResp.State
+		r.Read(ctx, resource.ReadRequest{State: readResp.State}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected an error diagnostic for a 401 response")
+		}
+		if resp.State.Raw.IsNull() {
+			t.Fatalf("a 401 response must not remove the key from state")
+		}
+	})
+
+	t.Run("Read removes deleted key", func(t *testing.T) {
+		clientFactory.OrganizationClient.EXPECT().GetProjectApiKey(contextWithDeadline(), projectID, projectApiKeyID).Return(nil, langfuse.ErrProjectApiKeyNotFound)
+
+		var resp resource.ReadResponse
+		resp.State = readResp.State
+		r.Read(ctx, resource.ReadRequest{State: readResp.State}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from Read: %v", resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Fatalf("expected the key to be removed from state")
+		}
+	})
+
+	t.Run("Update stores the planned timeouts", func(t *testing.T) {
+		plan := tfsdk.Plan{Raw: buildApiKeyObjectValue(map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"project_id":               tftypes.NewValue(tftypes.String, projectID),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
+			"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"note":                     tftypes.NewValue(tftypes.String, nil),
+			"public_key":               tftypes.NewValue(tftypes.String, publicKey),
+			"secret_key":               tftypes.NewValue(tftypes.String, privateKey),
+			"timeouts": tftypes.NewValue(createReadDeleteTimeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"read":   tftypes.NewValue(tftypes.String, "10m"),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}), Schema: resourceSchema}
+
+		var updateResp resource.UpdateResponse
+		updateResp.State = readResp.State
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+		if updateResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from Update: %v", updateResp.Diagnostics)
+		}
+
+		var got projectApiKeyResourceModel
+		if diags := updateResp.State.Get(ctx, &got); diags.HasError() {
+			t.Fatalf("unexpected diagnostics reading the state: %v", diags)
+		}
+		if got.ID.ValueString() != projectApiKeyID {
+			t.Fatalf("expected the key to be kept, got %q", got.ID.ValueString())
+		}
+		if readTimeout, _ := got.Timeouts.Read(ctx, 0); readTimeout != 10*time.Minute {
+			t.Fatalf("expected the planned read timeout, got %s", readTimeout)
+		}
+	})
+
+	t.Run("Update stores the planned credentials", func(t *testing.T) {
+		plan := tfsdk.Plan{Raw: buildApiKeyObjectValue(map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, projectApiKeyID),
+			"project_id":               tftypes.NewValue(tftypes.String, projectID),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+			"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+			"credentials":              tftypes.NewValue(tftypes.String, "team-b"),
+			"note":                     tftypes.NewValue(tftypes.String, nil),
+			"public_key":               tftypes.NewValue(tftypes.String, publicKey),
+			"secret_key":               tftypes.NewValue(tftypes.String, privateKey),
+			"timeouts":                 tftypes.NewValue(createReadDeleteTimeoutsType, nil),
+		}), Schema: resourceSchema}
+
+		var updateResp resource.UpdateResponse
+		updateResp.State = readResp.State
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+		if updateResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from Update: %v", updateResp.Diagnostics)
+		}
+
+		var got projectApiKeyResourceModel
+		if diags := updateResp.State.Get(ctx, &got); diags.HasError() {
+			t.Fatalf("unexpected diagnostics reading the state: %v", diags)
+		}
+		if got.Credentials.ValueString() != "team-b" || !got.OrganizationPublicKey.IsNull() || !got.OrganizationPrivateKey.IsNull() {
+			t.Fatalf("expected the planned credentials in the state, got %+v", got)
+		}
+	})
+
 	t.Run("Delete", func(t *testing.T) {
-		clientFactory.OrganizationClient.EXPECT().DeleteProjectApiKey(ctx, projectID, projectApiKeyID).Return(nil)
+		clientFactory.OrganizationClient.EXPECT().DeleteProjectApiKey(contextWithDeadline(), projectID, projectApiKeyID).Return(nil)
 
 		var deleteResp resource.DeleteResponse
 		deleteResp.State.Schema = resourceSchema
@@ -153,16 +250,19 @@ func buildApiKeyObjectValue(values map[string]tftypes.Value) tftypes.Value {
 				"id":                       tftypes.String,
 				"organization_public_key":  tftypes.String,
 				"organization_private_key": tftypes.String,
+				"credentials":              tftypes.String,
 				"project_id":               tftypes.String,
 				"note":                     tftypes.String,
 				"public_key":               tftypes.String,
 				"secret_key":               tftypes.String,
+				"timeouts":                 createReadDeleteTimeoutsType,
 			},
 			OptionalAttributes: map[string]struct{}{
 				"id":         {},
 				"note":       {},
 				"public_key": {},
 				"secret_key": {},
+				"timeouts":   {},
 			},
 		},
 		values,
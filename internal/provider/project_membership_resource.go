@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,6 +19,7 @@ import (
 )
 
 var _ resource.Resource = &projectMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &projectMembershipResource{}
 var _ resource.ResourceWithImportState = &projectMembershipResource{}
 
 func NewProjectMembershipResource() resource.Resource {
@@ -24,14 +27,16 @@ func NewProjectMembershipResource() resource.Resource {
 }
 
 type projectMembershipResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	ProjectID              types.String `tfsdk:"project_id"`
-	Email                  types.String `tfsdk:"email"`
-	Role                   types.String `tfsdk:"role"`
-	UserID                 types.String `tfsdk:"user_id"`
-	Name                   types.String `tfsdk:"name"`
-	OrganizationPublicKey  types.String `tfsdk:"organization_public_key"`
-	OrganizationPrivateKey types.String `tfsdk:"organization_private_key"`
+	ID                     types.String   `tfsdk:"id"`
+	ProjectID              types.String   `tfsdk:"project_id"`
+	Email                  types.String   `tfsdk:"email"`
+	Role                   types.String   `tfsdk:"role"`
+	UserID                 types.String   `tfsdk:"user_id"`
+	Name                   types.String   `tfsdk:"name"`
+	OrganizationPublicKey  types.String   `tfsdk:"organization_public_key"`
+	OrganizationPrivateKey types.String   `tfsdk:"organization_private_key"`
+	Credentials            types.String   `tfsdk:"credentials"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type projectMembershipResource struct {
@@ -59,6 +64,10 @@ func (r *projectMembershipResource) Metadata(ctx context.Context, req resource.M
 	resp.TypeName = req.ProviderTypeName + "_project_membership"
 }
 
+func (r *projectMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkOrganizationCredentials(ctx, r.ClientFactory, req.Plan, "langfuse_project_membership")...)
+}
+
 func (r *projectMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages membership in a Langfuse project.",
@@ -100,15 +109,26 @@ func (r *projectMembershipResource) Schema(ctx context.Context, req resource.Sch
 				Computed:    true,
 			},
 			"organization_public_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization public key to authenticate the call.",
+				Description: "Organization public key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_PUBLIC_KEY environment variable.",
 			},
 			"organization_private_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization private key to authenticate the call.",
+				Description: "Organization private key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_SECRET_KEY environment variable.",
 			},
+			"credentials": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a credential set of the provider whose organization keys authenticate the calls. Conflicts with organization_public_key and organization_private_key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("organization_public_key"), path.MatchRoot("organization_private_key")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -120,23 +140,30 @@ func (r *projectMembershipResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	role := data.Role.ValueString()
 
 	organizationClient := r.ClientFactory.NewOrganizationClient(
+		data.Credentials.ValueString(),
 		data.OrganizationPublicKey.ValueString(),
 		data.OrganizationPrivateKey.ValueString(),
 	)
 
 	// Look up user ID from email via organization memberships
-	memberships, err := organizationClient.ListMemberships(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Error listing organization memberships", err.Error())
-		return
-	}
-
 	var userID string
 	email := data.Email.ValueString()
-	for _, m := range memberships {
+	for m, err := range organizationClient.ListMemberships(ctx) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing organization memberships", err.Error())
+			return
+		}
 		if m.Email == email {
 			userID = m.UserID
 			break
@@ -170,6 +197,8 @@ func (r *projectMembershipResource) Create(ctx context.Context, req resource.Cre
 		Name:                   types.StringValue(membership.Name),
 		OrganizationPublicKey:  data.OrganizationPublicKey,
 		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
@@ -180,14 +209,23 @@ func (r *projectMembershipResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	organizationClient := r.ClientFactory.NewOrganizationClient(
+		state.Credentials.ValueString(),
 		state.OrganizationPublicKey.ValueString(),
 		state.OrganizationPrivateKey.ValueString(),
 	)
 
 	membership, err := organizationClient.GetProjectMembership(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if errors.Is(err, langfuse.ErrProjectMembershipNotFound) {
+		if langfuse.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -205,10 +243,18 @@ func (r *projectMembershipResource) Read(ctx context.Context, req resource.ReadR
 
 func (r *projectMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data projectMembershipResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	var state projectMembershipResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -218,9 +264,12 @@ func (r *projectMembershipResource) Update(ctx context.Context, req resource.Upd
 
 	role := data.Role.ValueString()
 
+	// The planned credentials authenticate the call: changing them only updates the membership in place, and
+	// the ones in the state may have been revoked.
 	organizationClient := r.ClientFactory.NewOrganizationClient(
-		state.OrganizationPublicKey.ValueString(),
-		state.OrganizationPrivateKey.ValueString(),
+		data.Credentials.ValueString(),
+		data.OrganizationPublicKey.ValueString(),
+		data.OrganizationPrivateKey.ValueString(),
 	)
 
 	updateRequest := &langfuse.CreateProjectMembershipRequest{
@@ -241,8 +290,10 @@ func (r *projectMembershipResource) Update(ctx context.Context, req resource.Upd
 		Role:                   types.StringValue(membership.Role),
 		UserID:                 types.StringValue(membership.UserID),
 		Name:                   types.StringValue(membership.Name),
-		OrganizationPublicKey:  state.OrganizationPublicKey,
-		OrganizationPrivateKey: state.OrganizationPrivateKey,
+		OrganizationPublicKey:  data.OrganizationPublicKey,
+		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
@@ -253,35 +304,53 @@ func (r *projectMembershipResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	organizationClient := r.ClientFactory.NewOrganizationClient(
+		state.Credentials.ValueString(),
 		state.OrganizationPublicKey.ValueString(),
 		state.OrganizationPrivateKey.ValueString(),
 	)
 
 	err := organizationClient.DeleteProjectMembership(ctx, state.ProjectID.ValueString(), state.UserID.ValueString())
-	if err != nil {
+	if err != nil && !langfuse.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error removing project member", err.Error())
 		return
 	}
 }
 
 func (r *projectMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: project_id,user_id,organization_public_key,organization_private_key
+	// Import format: project_id,user_id[,credentials | ,organization_public_key,organization_private_key]
 	// Example: terraform import langfuse_project_membership.example "proj_123,mem_456,pk_789,sk_012"
+	// Without keys, the organization credentials come from the named credential set, or from the provider.
 
 	importParts := strings.Split(req.ID, ",")
-	if len(importParts) != 4 {
+	if len(importParts) < 2 || len(importParts) > 4 || slices.Contains(importParts, "") {
 		resp.Diagnostics.AddError("Invalid import format",
-			"Import ID must be in format: project_id,user_id,organization_public_key,organization_private_key")
+			"Import ID must be in format: project_id,user_id[,credentials | ,organization_public_key,organization_private_key]")
 		return
 	}
 
 	projectID := importParts[0]
 	userID := importParts[1]
-	organizationPublicKey := importParts[2]
-	organizationPrivateKey := importParts[3]
+	credentials := types.StringNull()
+	organizationPublicKey := types.StringNull()
+	organizationPrivateKey := types.StringNull()
+	switch len(importParts) {
+	case 3:
+		credentials = types.StringValue(importParts[2])
+	case 4:
+		organizationPublicKey = types.StringValue(importParts[2])
+		organizationPrivateKey = types.StringValue(importParts[3])
+	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(organizationPublicKey, organizationPrivateKey)
+	organizationClient := r.ClientFactory.NewOrganizationClient(credentials.ValueString(), organizationPublicKey.ValueString(), organizationPrivateKey.ValueString())
 	membership, err := organizationClient.GetProjectMembership(ctx, projectID, userID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error importing project membership",
@@ -289,6 +358,12 @@ func (r *projectMembershipResource) ImportState(ctx context.Context, req resourc
 		return
 	}
 
+	importTimeouts, diags := importedTimeouts(ctx, resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &projectMembershipResourceModel{
 		ID:                     types.StringValue(membership.UserID),
 		ProjectID:              types.StringValue(projectID),
@@ -296,7 +371,9 @@ func (r *projectMembershipResource) ImportState(ctx context.Context, req resourc
 		Role:                   types.StringValue(membership.Role),
 		UserID:                 types.StringValue(membership.UserID),
 		Name:                   types.StringValue(membership.Name),
-		OrganizationPublicKey:  types.StringValue(organizationPublicKey),
-		OrganizationPrivateKey: types.StringValue(organizationPrivateKey),
+		OrganizationPublicKey:  organizationPublicKey,
+		OrganizationPrivateKey: organizationPrivateKey,
+		Credentials:            credentials,
+		Timeouts:               importTimeouts,
 	})...)
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
+	"go.uber.org/mock/gomock"
 )
 
 func TestProjectMembershipResourceMetadata(t *testing.T) {
@@ -174,17 +174,17 @@ func TestProjectMembershipResourceCRUD(t *testing.T) {
 	t.Run("Create", func(t *testing.T) {
 		// First, ListMemberships is called to resolve email to UserID
 		clientFactory.OrganizationClient.EXPECT().
-			ListMemberships(ctx).
-			Return([]langfuse.OrganizationMembership{
+			ListMemberships(contextWithDeadline()).
+			Return(seqOf([]langfuse.OrganizationMembership{
 				{
 					ID:     "orgmem-123",
 					Email:  userEmail,
 					UserID: "user-789",
 				},
-			}, nil)
+			}))
 
 		clientFactory.OrganizationClient.EXPECT().
-			CreateOrUpdateProjectMembership(ctx, projectID, &langfuse.CreateProjectMembershipRequest{
+			CreateOrUpdateProjectMembership(contextWithDeadline(), projectID, &langfuse.CreateProjectMembershipRequest{
 				UserID: "user-789",
 				Role:   "MEMBER",
 			}).
@@ -211,7 +211,7 @@ func TestProjectMembershipResourceCRUD(t *testing.T) {
 	var readResp resource.ReadResponse
 	t.Run("Read", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			GetProjectMembership(ctx, projectID, "user-789").
+			GetProjectMembership(contextWithDeadline(), projectID, "user-789").
 			Return(&langfuse.ProjectMembership{
 				UserID: "user-789",
 				Role:   "MEMBER",
@@ -231,7 +231,7 @@ func TestProjectMembershipResourceCRUD(t *testing.T) {
 	var updateResp resource.UpdateResponse
 	t.Run("Update", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			CreateOrUpdateProjectMembership(ctx, projectID, &langfuse.CreateProjectMembershipRequest{
+			CreateOrUpdateProjectMembership(contextWithDeadline(), projectID, &langfuse.CreateProjectMembershipRequest{
 				UserID: "user-789",
 				Role:   "ADMIN",
 			}).
@@ -242,22 +242,67 @@ func TestProjectMembershipResourceCRUD(t *testing.T) {
 				Name:   "developer",
 			}, nil)
 
-		updateConfig := tfsdk.Config{
+		updatePlan := tfsdk.Plan{
 			Raw:    buildProjectMembershipStateValue(projectID, userEmail, "ADMIN", "user-789", "developer", publicKey, privateKey),
 			Schema: resourceSchema,
 		}
 		updateResp.State.Schema = resourceSchema
 
-		r.Update(ctx, resource.UpdateRequest{Config: updateConfig, State: readResp.State}, &updateResp)
+		r.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: readResp.State}, &updateResp)
 
 		if updateResp.Diagnostics.HasError() {
 			t.Fatalf("unexpected diagnostics from Update: %v", updateResp.Diagnostics)
 		}
 	})
 
+	t.Run("Update with another credential set", func(t *testing.T) {
+		clientFactory.OrganizationClient.EXPECT().
+			CreateOrUpdateProjectMembership(contextWithDeadline(), projectID, &langfuse.CreateProjectMembershipRequest{
+				UserID: "user-789",
+				Role:   "ADMIN",
+			}).
+			Return(&langfuse.ProjectMembership{
+				UserID: "user-789",
+				Role:   "ADMIN",
+				Email:  userEmail,
+				Name:   "developer",
+			}, nil)
+
+		plan := tfsdk.Plan{
+			Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"id":                       tftypes.NewValue(tftypes.String, "user-789"),
+				"project_id":               tftypes.NewValue(tftypes.String, projectID),
+				"email":                    tftypes.NewValue(tftypes.String, userEmail),
+				"role":                     tftypes.NewValue(tftypes.String, "ADMIN"),
+				"user_id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"name":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+				"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+				"credentials":              tftypes.NewValue(tftypes.String, "team-b"),
+				"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+			}),
+			Schema: resourceSchema,
+		}
+
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: resourceSchema}}
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: updateResp.State}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from Update: %v", resp.Diagnostics)
+		}
+		if got := clientFactory.OrganizationClientCredentials; got != (mocks.ClientCredentials{CredentialSet: "team-b"}) {
+			t.Fatalf("expected the planned credential set to authenticate the update, got %+v", got)
+		}
+
+		var state projectMembershipResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if state.Credentials.ValueString() != "team-b" || !state.OrganizationPublicKey.IsNull() || !state.OrganizationPrivateKey.IsNull() {
+			t.Fatalf("expected the planned credentials in the state, got %+v", state)
+		}
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			DeleteProjectMembership(ctx, projectID, "user-789").
+			DeleteProjectMembership(contextWithDeadline(), projectID, "user-789").
 			Return(nil)
 
 		var deleteResp resource.DeleteResponse
@@ -307,14 +352,14 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Create_UserNotFoundInOrganization", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			ListMemberships(ctx).
-			Return([]langfuse.OrganizationMembership{
+			ListMemberships(contextWithDeadline()).
+			Return(seqOf([]langfuse.OrganizationMembership{
 				{
 					ID:     "orgmem-999",
 					Email:  "other@company.com",
 					UserID: "user-999",
 				},
-			}, nil)
+			}))
 
 		createConfig := tfsdk.Config{
 			Raw:    buildProjectMembershipObjectValue(projectID, userEmail, "MEMBER", publicKey, privateKey),
@@ -345,8 +390,8 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Create_ListMembershipsError", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			ListMemberships(ctx).
-			Return(nil, fmt.Errorf("API error: rate limit exceeded"))
+			ListMemberships(contextWithDeadline()).
+			Return(seqError[langfuse.OrganizationMembership](fmt.Errorf("API error: rate limit exceeded")))
 
 		createConfig := tfsdk.Config{
 			Raw:    buildProjectMembershipObjectValue(projectID, userEmail, "MEMBER", publicKey, privateKey),
@@ -377,17 +422,17 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Create_CreateOrUpdateProjectMembershipError", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			ListMemberships(ctx).
-			Return([]langfuse.OrganizationMembership{
+			ListMemberships(contextWithDeadline()).
+			Return(seqOf([]langfuse.OrganizationMembership{
 				{
 					ID:     "orgmem-123",
 					Email:  userEmail,
 					UserID: "user-789",
 				},
-			}, nil)
+			}))
 
 		clientFactory.OrganizationClient.EXPECT().
-			CreateOrUpdateProjectMembership(ctx, projectID, &langfuse.CreateProjectMembershipRequest{
+			CreateOrUpdateProjectMembership(contextWithDeadline(), projectID, &langfuse.CreateProjectMembershipRequest{
 				UserID: "user-789",
 				Role:   "MEMBER",
 			}).
@@ -422,7 +467,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Read_GetProjectMembershipError", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			GetProjectMembership(ctx, projectID, "user-789").
+			GetProjectMembership(contextWithDeadline(), projectID, "user-789").
 			Return(nil, fmt.Errorf("API error: service unavailable"))
 
 		state := tfsdk.State{
@@ -454,7 +499,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Read_MembershipNotFound_RemovesResource", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			GetProjectMembership(ctx, projectID, "user-789").
+			GetProjectMembership(contextWithDeadline(), projectID, "user-789").
 			Return(nil, fmt.Errorf("%w: user-789 in project proj-123", langfuse.ErrProjectMembershipNotFound))
 
 		state := tfsdk.State{
@@ -480,7 +525,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Update_CreateOrUpdateProjectMembershipError", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			CreateOrUpdateProjectMembership(ctx, projectID, &langfuse.CreateProjectMembershipRequest{
+			CreateOrUpdateProjectMembership(contextWithDeadline(), projectID, &langfuse.CreateProjectMembershipRequest{
 				UserID: "user-789",
 				Role:   "ADMIN",
 			}).
@@ -491,7 +536,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 			Schema: resourceSchema,
 		}
 
-		config := tfsdk.Config{
+		plan := tfsdk.Plan{
 			Raw:    buildProjectMembershipStateValue(projectID, userEmail, "ADMIN", "user-789", "developer", publicKey, privateKey),
 			Schema: resourceSchema,
 		}
@@ -499,7 +544,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 		var updateResp resource.UpdateResponse
 		updateResp.State.Schema = resourceSchema
 
-		r.Update(ctx, resource.UpdateRequest{Config: config, State: state}, &updateResp)
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
 
 		if !updateResp.Diagnostics.HasError() {
 			t.Fatal("expected error when CreateOrUpdateProjectMembership fails during update, but got none")
@@ -520,7 +565,7 @@ func TestProjectMembershipResourceErrorHandling(t *testing.T) {
 
 	t.Run("Delete_DeleteProjectMembershipError", func(t *testing.T) {
 		clientFactory.OrganizationClient.EXPECT().
-			DeleteProjectMembership(ctx, projectID, "user-789").
+			DeleteProjectMembership(contextWithDeadline(), projectID, "user-789").
 			Return(fmt.Errorf("API error: cannot remove last owner"))
 
 		state := tfsdk.State{
@@ -632,12 +677,59 @@ func TestProjectMembershipResourceImport(t *testing.T) {
 		}
 	})
 
+	t.Run("Import without keys", func(t *testing.T) {
+		clientFactory.OrganizationClient.EXPECT().
+			GetProjectMembership(ctx, projectID, userID).
+			Return(&langfuse.ProjectMembership{UserID: userID, Role: "VIEWER", Email: "imported@example.com"}, nil)
+
+		var importResp resource.ImportStateResponse
+		importResp.State.Schema = schemaResp.Schema
+
+		r.ImportState(ctx, resource.ImportStateRequest{ID: projectID + "," + userID}, &importResp)
+
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from ImportState: %v", importResp.Diagnostics)
+		}
+
+		// The organization credentials come from the environment and stay out of state.
+		var model projectMembershipResourceModel
+		if diags := importResp.State.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("unexpected diagnostics getting model from imported state: %v", diags)
+		}
+		if !model.OrganizationPublicKey.IsNull() || !model.OrganizationPrivateKey.IsNull() {
+			t.Errorf("expected organization keys to be null, got %q and %q", model.OrganizationPublicKey.ValueString(), model.OrganizationPrivateKey.ValueString())
+		}
+	})
+
+	t.Run("Import with credential set", func(t *testing.T) {
+		clientFactory.OrganizationClient.EXPECT().
+			GetProjectMembership(ctx, projectID, userID).
+			Return(&langfuse.ProjectMembership{UserID: userID, Role: "VIEWER", Email: "imported@example.com"}, nil)
+
+		var importResp resource.ImportStateResponse
+		importResp.State.Schema = schemaResp.Schema
+
+		r.ImportState(ctx, resource.ImportStateRequest{ID: projectID + "," + userID + ",team-a"}, &importResp)
+
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics from ImportState: %v", importResp.Diagnostics)
+		}
+
+		var model projectMembershipResourceModel
+		if diags := importResp.State.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("unexpected diagnostics getting model from imported state: %v", diags)
+		}
+		if model.Credentials.ValueString() != "team-a" || !model.OrganizationPublicKey.IsNull() {
+			t.Errorf("expected the credential set to be kept instead of keys, got %q and %q", model.Credentials.ValueString(), model.OrganizationPublicKey.ValueString())
+		}
+	})
+
 	t.Run("Invalid import format", func(t *testing.T) {
 		testCases := []struct {
 			name     string
 			importID string
 		}{
-			{"too_few_parts", "proj-123,user-789"},
+			{"empty_credentials", "proj-123,user-789,"},
 			{"too_many_parts", "proj-123,user-789,pk-1234,sk-1234,extra"},
 			{"single_part", "proj-123"},
 			{"empty_string", ""},
@@ -711,11 +803,14 @@ func buildProjectMembershipObjectValue(projectID, email, role, publicKey, privat
 				"name":                     tftypes.String,
 				"organization_public_key":  tftypes.String,
 				"organization_private_key": tftypes.String,
+				"credentials":              tftypes.String,
+				"timeouts":                 crudTimeoutsType,
 			},
 			OptionalAttributes: map[string]struct{}{
-				"id":      {},
-				"user_id": {},
-				"name":    {},
+				"id":       {},
+				"user_id":  {},
+				"name":     {},
+				"timeouts": {},
 			},
 		},
 		map[string]tftypes.Value{
@@ -727,6 +822,8 @@ func buildProjectMembershipObjectValue(projectID, email, role, publicKey, privat
 			"name":                     tftypes.NewValue(tftypes.String, nil),
 			"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
 			"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 		},
 	)
 }
@@ -743,11 +840,14 @@ func buildProjectMembershipStateValue(projectID, email, role, userID, name, publ
 				"name":                     tftypes.String,
 				"organization_public_key":  tftypes.String,
 				"organization_private_key": tftypes.String,
+				"credentials":              tftypes.String,
+				"timeouts":                 crudTimeoutsType,
 			},
 			OptionalAttributes: map[string]struct{}{
-				"id":      {},
-				"user_id": {},
-				"name":    {},
+				"id":       {},
+				"user_id":  {},
+				"name":     {},
+				"timeouts": {},
 			},
 		},
 		map[string]tftypes.Value{
@@ -759,6 +859,8 @@ func buildProjectMembershipStateValue(projectID, email, role, userID, name, publ
 			"name":                     tftypes.NewValue(tftypes.String, name),
 			"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
 			"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 		},
 	)
 }
@@ -2,19 +2,23 @@ package provider
 
 import (
 	"context"
+	"slices"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 )
 
 var _ resource.Resource = &projectResource{}
+var _ resource.ResourceWithModifyPlan = &projectResource{}
 var _ resource.ResourceWithImportState = &projectResource{}
 
 func NewProjectResource() resource.Resource {
@@ -22,13 +26,17 @@ func NewProjectResource() resource.Resource {
 }
 
 type projectResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	Name                   types.String `tfsdk:"name"`
-	RetentionDays          types.Int32  `tfsdk:"retention_days"`
-	Metadata               types.Map    `tfsdk:"metadata"`
-	OrganizationID         types.String `tfsdk:"organization_id"`
-	OrganizationPublicKey  types.String `tfsdk:"organization_public_key"`
-	OrganizationPrivateKey types.String `tfsdk:"organization_private_key"`
+	ID                     types.String   `tfsdk:"id"`
+	Name                   types.String   `tfsdk:"name"`
+	RetentionDays          types.Int32    `tfsdk:"retention_days"`
+	Metadata               types.Map      `tfsdk:"metadata"`
+	MetadataAll            types.Map      `tfsdk:"metadata_all"`
+	DeletionProtection     types.Bool     `tfsdk:"deletion_protection"`
+	OrganizationID         types.String   `tfsdk:"organization_id"`
+	OrganizationPublicKey  types.String   `tfsdk:"organization_public_key"`
+	OrganizationPrivateKey types.String   `tfsdk:"organization_private_key"`
+	Credentials            types.String   `tfsdk:"credentials"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type projectResource struct {
@@ -47,6 +55,12 @@ func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_project"
 }
 
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkOrganizationCredentials(ctx, r.ClientFactory, req.Plan, "langfuse_project")...)
+	resp.Diagnostics.Append(planMetadataAll(ctx, r.ClientFactory, &resp.Plan)...)
+	resp.Diagnostics.Append(planDeletionProtection(ctx, r.ClientFactory, req.Config, &resp.Plan)...)
+}
+
 func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -62,13 +76,23 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"retention_days": schema.Int32Attribute{
 				Optional:    true,
-                Computed: true,
+				Computed:    true,
 				Description: "The retention period for the project in days. If not set, or set with a value of 0, data will be stored indefinitely.",
 			},
 			"metadata": schema.MapAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
-				Description: "Metadata for the project as key-value pairs.",
+				Description: "Metadata for the project as key-value pairs. Merged over the default_metadata of the provider.",
+			},
+			"metadata_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Metadata of the project, including the default_metadata of the provider.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Refuse to delete the project, including when replacing it. Turning it off takes an apply of its own before the project can be deleted. Defaults to the default_deletion_protection of the provider.",
 			},
 			"organization_id": schema.StringAttribute{
 				Required:    true,
@@ -78,21 +102,32 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"organization_public_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization public key to authenticate the call.",
+				Description: "Organization public key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_PUBLIC_KEY environment variable.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"organization_private_key": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Organization private key to authenticate the call.",
+				Description: "Organization private key to authenticate the call. Falls back to the organization block of the provider, then to the LANGFUSE_ORGANIZATION_SECRET_KEY environment variable.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"credentials": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a credential set of the provider whose organization keys authenticate the calls. Conflicts with organization_public_key and organization_private_key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("organization_public_key"), path.MatchRoot("organization_private_key")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -105,6 +140,16 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
 	metadata := make(map[string]string)
 	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
 		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
@@ -112,8 +157,9 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 	}
+	metadata = mergeMetadata(defaultMetadata(r.ClientFactory), metadata)
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	project, err := organizationClient.CreateProject(ctx, &langfuse.CreateProjectRequest{
 		Name:          data.Name.ValueString(),
 		RetentionDays: data.RetentionDays.ValueInt32(),
@@ -124,16 +170,10 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	var metadataMap types.Map
-	if len(project.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, project.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, project.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &projectResourceModel{
@@ -141,9 +181,13 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		Name:                   types.StringValue(project.Name),
 		RetentionDays:          types.Int32Value(project.RetentionDays),
 		Metadata:               metadataMap,
+		MetadataAll:            metadataAll,
+		DeletionProtection:     deletionProtection,
 		OrganizationID:         types.StringValue(data.OrganizationID.ValueString()),
-		OrganizationPublicKey:  types.StringValue(data.OrganizationPublicKey.ValueString()),
-		OrganizationPrivateKey: types.StringValue(data.OrganizationPrivateKey.ValueString()),
+		OrganizationPublicKey:  data.OrganizationPublicKey,
+		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
@@ -155,23 +199,32 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	project, err := organizationClient.GetProject(ctx, data.ID.ValueString())
 	if err != nil {
+		if langfuse.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading project", err.Error())
 		return
 	}
 
-	var metadataMap types.Map
-	if len(project.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, project.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	// The state may have been written before deletion_protection existed.
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
+	metadataMap, metadataAll, diags := metadataState(ctx, project.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Note: retention_days is write-only in the Langfuse API and not returned in responses.
@@ -180,9 +233,13 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		Name:                   types.StringValue(project.Name),
 		RetentionDays:          data.RetentionDays,
 		Metadata:               metadataMap,
+		MetadataAll:            metadataAll,
+		DeletionProtection:     deletionProtection,
 		OrganizationID:         types.StringValue(data.OrganizationID.ValueString()),
-		OrganizationPublicKey:  types.StringValue(data.OrganizationPublicKey.ValueString()),
-		OrganizationPrivateKey: types.StringValue(data.OrganizationPrivateKey.ValueString()),
+		OrganizationPublicKey:  data.OrganizationPublicKey,
+		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
@@ -194,6 +251,14 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get ID from current state (ID is not in config during updates)
 	var currentState projectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &currentState)...)
@@ -203,6 +268,8 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	projectID := currentState.ID.ValueString()
 
+	deletionProtection := deletionProtectionOrDefault(data.DeletionProtection, r.ClientFactory)
+
 	metadata := make(map[string]string)
 	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
 		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
@@ -210,8 +277,9 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 			return
 		}
 	}
+	metadata = mergeMetadata(defaultMetadata(r.ClientFactory), metadata)
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 
 	request := &langfuse.UpdateProjectRequest{
 		Name:          data.Name.ValueString(),
@@ -225,16 +293,10 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	var metadataMap types.Map
-	if len(project.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, project.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, project.Metadata, defaultMetadata(r.ClientFactory), data.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &projectResourceModel{
@@ -242,23 +304,36 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		Name:                   types.StringValue(project.Name),
 		RetentionDays:          data.RetentionDays, // Use from config, not API response
 		Metadata:               metadataMap,
+		MetadataAll:            metadataAll,
+		DeletionProtection:     deletionProtection,
 		OrganizationID:         types.StringValue(data.OrganizationID.ValueString()),
-		OrganizationPublicKey:  types.StringValue(data.OrganizationPublicKey.ValueString()),
-		OrganizationPrivateKey: types.StringValue(data.OrganizationPrivateKey.ValueString()),
+		OrganizationPublicKey:  data.OrganizationPublicKey,
+		OrganizationPrivateKey: data.OrganizationPrivateKey,
+		Credentials:            data.Credentials,
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data projectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "langfuse_project", data.ID.ValueString())...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	organizationClient := r.ClientFactory.NewOrganizationClient(data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	organizationClient := r.ClientFactory.NewOrganizationClient(data.Credentials.ValueString(), data.OrganizationPublicKey.ValueString(), data.OrganizationPrivateKey.ValueString())
 	err := organizationClient.DeleteProject(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !langfuse.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error deleting project", err.Error())
 		return
 	}
@@ -268,30 +343,41 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		Name:                   types.StringValue(""),
 		RetentionDays:          types.Int32Value(0),
 		Metadata:               types.MapNull(types.StringType),
+		MetadataAll:            types.MapNull(types.StringType),
 		OrganizationID:         types.StringValue(""),
 		OrganizationPublicKey:  types.StringValue(""),
 		OrganizationPrivateKey: types.StringValue(""),
+		Timeouts:               data.Timeouts,
 	})...)
 }
 
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: project_id,organization_id,organization_public_key,organization_private_key
+	// Import format: project_id,organization_id[,credentials | ,organization_public_key,organization_private_key]
 	// Example: terraform import langfuse_project.example "proj_123,org_456,pk_789,sk_012"
+	// Without keys, the organization credentials come from the named credential set, or from the provider.
 
 	importParts := strings.Split(req.ID, ",")
-	if len(importParts) != 4 {
+	if len(importParts) < 2 || len(importParts) > 4 || slices.Contains(importParts, "") {
 		resp.Diagnostics.AddError("Invalid import format",
-			"Import ID must be in format: project_id,organization_id,organization_public_key,organization_private_key")
+			"Import ID must be in format: project_id,organization_id[,credentials | ,organization_public_key,organization_private_key]")
 		return
 	}
 
 	projectID := importParts[0]
 	organizationID := importParts[1]
-	organizationPublicKey := importParts[2]
-	organizationPrivateKey := importParts[3]
+	credentials := types.StringNull()
+	organizationPublicKey := types.StringNull()
+	organizationPrivateKey := types.StringNull()
+	switch len(importParts) {
+	case 3:
+		credentials = types.StringValue(importParts[2])
+	case 4:
+		organizationPublicKey = types.StringValue(importParts[2])
+		organizationPrivateKey = types.StringValue(importParts[3])
+	}
 
 	// Get the project details using the provided organization credentials
-	organizationClient := r.ClientFactory.NewOrganizationClient(organizationPublicKey, organizationPrivateKey)
+	organizationClient := r.ClientFactory.NewOrganizationClient(credentials.ValueString(), organizationPublicKey.ValueString(), organizationPrivateKey.ValueString())
 	project, err := organizationClient.GetProject(ctx, projectID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error importing project",
@@ -299,17 +385,16 @@ func (r *projectResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 
-	// Convert metadata to the appropriate type
-	var metadataMap types.Map
-	if len(project.Metadata) > 0 {
-		var diags diag.Diagnostics
-		metadataMap, diags = types.MapValueFrom(ctx, types.StringType, project.Metadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		metadataMap = types.MapNull(types.StringType)
+	metadataMap, metadataAll, diags := metadataState(ctx, project.Metadata, defaultMetadata(r.ClientFactory), types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	importTimeouts, diags := importedTimeouts(ctx, resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set the imported state with all required information
@@ -318,9 +403,13 @@ func (r *projectResource) ImportState(ctx context.Context, req resource.ImportSt
 		Name:                   types.StringValue(project.Name),
 		RetentionDays:          types.Int32Value(0), // Default value since retention_days is write-only in Langfuse API
 		Metadata:               metadataMap,
+		MetadataAll:            metadataAll,
+		DeletionProtection:     defaultDeletionProtection(r.ClientFactory),
 		OrganizationID:         types.StringValue(organizationID),
-		OrganizationPublicKey:  types.StringValue(organizationPublicKey),
-		OrganizationPrivateKey: types.StringValue(organizationPrivateKey),
+		OrganizationPublicKey:  organizationPublicKey,
+		OrganizationPrivateKey: organizationPrivateKey,
+		Credentials:            credentials,
+		Timeouts:               importTimeouts,
 	})...)
 
 	// Set the ID attribute explicitly to just the project ID (not the full import string)
@@ -7,8 +7,9 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
 
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/mocks"
@@ -146,7 +147,7 @@ func TestProjectResourceCRUD(t *testing.T) {
 			RetentionDays: 0,
 			Metadata:      createMetadata,
 		}
-		clientFactory.OrganizationClient.EXPECT().CreateProject(ctx, expectedProject).Return(&langfuse.Project{
+		clientFactory.OrganizationClient.EXPECT().CreateProject(contextWithDeadline(), expectedProject).Return(&langfuse.Project{
 			ID:            projectID,
 			Name:          createName,
 			RetentionDays: 0,
@@ -167,6 +168,10 @@ func TestProjectResourceCRUD(t *testing.T) {
 				"organization_id":          tftypes.NewValue(tftypes.String, organizationID),
 				"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
 				"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+				"credentials":              tftypes.NewValue(tftypes.String, nil),
+				"metadata_all":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"deletion_protection":      tftypes.NewValue(tftypes.Bool, nil),
+				"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 			}),
 			Schema: resourceSchema,
 		}
@@ -179,7 +184,7 @@ func TestProjectResourceCRUD(t *testing.T) {
 
 	var readResp resource.ReadResponse
 	t.Run("Read", func(t *testing.T) {
-		clientFactory.OrganizationClient.EXPECT().GetProject(ctx, "proj-123").Return(&langfuse.Project{
+		clientFactory.OrganizationClient.EXPECT().GetProject(contextWithDeadline(), "proj-123").Return(&langfuse.Project{
 			ID:            "proj-123",
 			Name:          createName,
 			RetentionDays: 0,
@@ -198,7 +203,7 @@ func TestProjectResourceCRUD(t *testing.T) {
 		newName := "ChatQA Plus"
 		newRetention := int32(30)
 		newMetadata := map[string]string{"environment": "production", "team": "ai", "version": "2.0"}
-		clientFactory.OrganizationClient.EXPECT().UpdateProject(ctx, "proj-123", &langfuse.UpdateProjectRequest{
+		clientFactory.OrganizationClient.EXPECT().UpdateProject(contextWithDeadline(), "proj-123", &langfuse.UpdateProjectRequest{
 			Name:          newName,
 			RetentionDays: newRetention,
 			Metadata:      newMetadata,
@@ -224,6 +229,10 @@ func TestProjectResourceCRUD(t *testing.T) {
 				"organization_id":          tftypes.NewValue(tftypes.String, organizationID),
 				"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
 				"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+				"credentials":              tftypes.NewValue(tftypes.String, nil),
+				"metadata_all":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"deletion_protection":      tftypes.NewValue(tftypes.Bool, nil),
+				"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 			}),
 			Schema: resourceSchema,
 		}
@@ -235,7 +244,7 @@ func TestProjectResourceCRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
-		clientFactory.OrganizationClient.EXPECT().DeleteProject(ctx, "proj-123").Return(nil)
+		clientFactory.OrganizationClient.EXPECT().DeleteProject(contextWithDeadline(), "proj-123").Return(nil)
 
 		var deleteResp resource.DeleteResponse
 		deleteResp.State.Schema = resourceSchema
@@ -252,7 +261,7 @@ func TestProjectResourceCRUD(t *testing.T) {
 
 		clientFactory := mocks.NewMockClientFactory(ctrl)
 
-		clientFactory.OrganizationClient.EXPECT().GetProject(ctx, "proj-123").Return(&langfuse.Project{
+		clientFactory.OrganizationClient.EXPECT().GetProject(contextWithDeadline(), "proj-123").Return(&langfuse.Project{
 			ID:            "proj-123",
 			Name:          "test-project",
 			RetentionDays: 0, // API returns 0 (doesn't return actual value)
@@ -273,6 +282,10 @@ func TestProjectResourceCRUD(t *testing.T) {
 			"organization_id":          tftypes.NewValue(tftypes.String, organizationID),
 			"organization_public_key":  tftypes.NewValue(tftypes.String, "pub-key"),
 			"organization_private_key": tftypes.NewValue(tftypes.String, "priv-key"),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"metadata_all":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"deletion_protection":      tftypes.NewValue(tftypes.Bool, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
 		})
 
 		var readResp resource.ReadResponse
@@ -295,6 +308,104 @@ func TestProjectResourceCRUD(t *testing.T) {
 	})
 }
 
+func TestProjectResourceCreateHonoursTimeouts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	clientFactory := mocks.NewMockClientFactory(ctrl)
+	r := &projectResource{ClientFactory: clientFactory}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var remaining time.Duration
+	clientFactory.OrganizationClient.EXPECT().CreateProject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *langfuse.CreateProjectRequest) (*langfuse.Project, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatalf("expected Create to pass a context with a deadline")
+			}
+			remaining = time.Until(deadline)
+			return &langfuse.Project{ID: "proj-123", Name: "ChatQA"}, nil
+		})
+
+	config := tfsdk.Config{
+		Raw: buildProjectObjectValue(map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, nil),
+			"name":                     tftypes.NewValue(tftypes.String, "ChatQA"),
+			"retention_days":           tftypes.NewValue(tftypes.Number, nil),
+			"metadata":                 tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"organization_id":          tftypes.NewValue(tftypes.String, "org-123"),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, "pk-1234"),
+			"organization_private_key": tftypes.NewValue(tftypes.String, "sk-1234"),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts": tftypes.NewValue(crudTimeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, "30s"),
+				"read":   tftypes.NewValue(tftypes.String, nil),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}),
+			"metadata_all":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+		}),
+		Schema: schemaResp.Schema,
+	}
+
+	var createResp resource.CreateResponse
+	createResp.State.Schema = schemaResp.Schema
+	r.Create(ctx, resource.CreateRequest{Config: config}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics from Create: %v", createResp.Diagnostics)
+	}
+
+	if remaining <= 0 || remaining > 30*time.Second {
+		t.Fatalf("expected the configured 30s create timeout to bound the request, got %s", remaining)
+	}
+}
+
+func TestProjectResourceDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	// No call is expected from the mocked client: the project must not be deleted.
+	clientFactory := mocks.NewMockClientFactory(ctrl)
+	r := &projectResource{ClientFactory: clientFactory}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw: buildProjectObjectValue(map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "proj-123"),
+			"name":                     tftypes.NewValue(tftypes.String, "ChatQA"),
+			"organization_id":          tftypes.NewValue(tftypes.String, "org-123"),
+			"deletion_protection":      tftypes.NewValue(tftypes.Bool, true),
+			"retention_days":           tftypes.NewValue(tftypes.Number, nil),
+			"metadata":                 tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"metadata_all":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			"organization_public_key":  tftypes.NewValue(tftypes.String, nil),
+			"organization_private_key": tftypes.NewValue(tftypes.String, nil),
+			"credentials":              tftypes.NewValue(tftypes.String, nil),
+			"timeouts":                 tftypes.NewValue(crudTimeoutsType, nil),
+		}),
+	}
+
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+
+	if !deleteResp.Diagnostics.HasError() {
+		t.Fatalf("expected Delete to refuse a project with deletion protection")
+	}
+	if !deleteResp.State.Raw.Equal(state.Raw) {
+		t.Fatalf("expected the protected project to stay in state")
+	}
+}
+
 func TestProjectResourceImport(t *testing.T) {
 	t.Parallel()
 
@@ -451,26 +562,32 @@ func TestProjectResourceImport(t *testing.T) {
 }
 
 func buildProjectObjectValue(values map[string]tftypes.Value) tftypes.Value {
-	return tftypes.NewValue(
-		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":                       tftypes.String,
-				"name":                     tftypes.String,
-				"retention_days":           tftypes.Number,
-				"metadata":                 tftypes.Map{ElementType: tftypes.String},
-				"organization_id":          tftypes.String,
-				"organization_public_key":  tftypes.String,
-				"organization_private_key": tftypes.String,
-			},
-			OptionalAttributes: map[string]struct{}{
-				"id":                       {},
-				"retention_days":           {},
-				"metadata":                 {},
-				"organization_id":          {},
-				"organization_public_key":  {},
-				"organization_private_key": {},
-			},
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                       tftypes.String,
+			"name":                     tftypes.String,
+			"retention_days":           tftypes.Number,
+			"metadata":                 tftypes.Map{ElementType: tftypes.String},
+			"metadata_all":             tftypes.Map{ElementType: tftypes.String},
+			"deletion_protection":      tftypes.Bool,
+			"organization_id":          tftypes.String,
+			"organization_public_key":  tftypes.String,
+			"organization_private_key": tftypes.String,
+			"credentials":              tftypes.String,
+			"timeouts":                 crudTimeoutsType,
 		},
-		values,
-	)
+		OptionalAttributes: map[string]struct{}{
+			"id":                       {},
+			"retention_days":           {},
+			"metadata":                 {},
+			"metadata_all":             {},
+			"deletion_protection":      {},
+			"organization_id":          {},
+			"organization_public_key":  {},
+			"organization_private_key": {},
+			"credentials":              {},
+			"timeouts":                 {},
+		},
+	}
+	return tftypes.NewValue(objectType, values)
 }
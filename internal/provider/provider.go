@@ -1,26 +1,92 @@
 package provider
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 )
 
 var _ provider.Provider = &langfuseProvider{}
 
+// defaultHost is used when neither the configuration, the environment nor the credentials profile set a host.
+const defaultHost = "https://app.langfuse.com"
+
 type langfuseProvider struct {
 	version string
+	// clientOptions are appended to the options derived from the configuration, e.g. by tests to record or
+	// replay the exchanges with the server.
+	clientOptions []langfuse.ClientFactoryOption
 }
 
 type langfuseProviderModel struct {
-	Host        types.String `tfsdk:"host"`
-	AdminAPIKey types.String `tfsdk:"admin_api_key"`
+	Host                      types.String  `tfsdk:"host"`
+	Profile                   types.String  `tfsdk:"profile"`
+	CredentialProcess         types.String  `tfsdk:"credential_process"`
+	AdminAPIKey               types.String  `tfsdk:"admin_api_key"`
+	MaxRetries                types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait              types.Int64   `tfsdk:"retry_max_wait"`
+	RequestTimeout            types.Int64   `tfsdk:"request_timeout"`
+	RequestsPerSecond         types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests     types.Int64   `tfsdk:"max_concurrent_requests"`
+	CACertFile                types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM                 types.String  `tfsdk:"ca_cert_pem"`
+	ClientCert                types.String  `tfsdk:"client_cert"`
+	ClientKey                 types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify        types.Bool    `tfsdk:"insecure_skip_verify"`
+	ProxyURL                  types.String  `tfsdk:"proxy_url"`
+	UserAgentSuffix           types.String  `tfsdk:"user_agent_suffix"`
+	SkipCredentialsValidation types.Bool    `tfsdk:"skip_credentials_validation"`
+	ReadOnly                  types.Bool    `tfsdk:"read_only"`
+	Credentials               types.Map     `tfsdk:"credentials"`
+	DefaultMetadata           types.Map     `tfsdk:"default_metadata"`
+	DefaultDeletionProtection types.Bool    `tfsdk:"default_deletion_protection"`
+
+	Organization *organizationCredentialsModel `tfsdk:"organization"`
+	Project      *projectCredentialsModel      `tfsdk:"project"`
+}
+
+// organizationCredentialsModel is the organization block of the provider, holding the keys used by the
+// organization-scoped resources that do not set their own.
+type organizationCredentialsModel struct {
+	PublicKey  types.String `tfsdk:"public_key"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
+
+// credentialSetModel is an entry of the credentials map of the provider, selected by resources through their
+// credentials attribute.
+type credentialSetModel struct {
+	OrganizationPublicKey  types.String `tfsdk:"organization_public_key"`
+	OrganizationPrivateKey types.String `tfsdk:"organization_private_key"`
+	ProjectPublicKey       types.String `tfsdk:"project_public_key"`
+	ProjectSecretKey       types.String `tfsdk:"project_secret_key"`
+}
+
+// projectCredentialsModel is the project block of the provider, holding the keys used by the project-scoped
+// resources that do not set their own.
+type projectCredentialsModel struct {
+	PublicKey types.String `tfsdk:"public_key"`
+	SecretKey types.String `tfsdk:"secret_key"`
 }
 
 func (p *langfuseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -33,12 +99,185 @@ func (p *langfuseProvider) Schema(ctx context.Context, req provider.SchemaReques
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
 				Optional:    true,
-				Description: "Base URI of the Langfuse instance (defaults to https://app.langfuse.com).",
+				Description: "Base URI of the Langfuse instance (defaults to https://app.langfuse.com). Can also come from LANGFUSE_HOST, or from the host of the credentials profile.",
+			},
+			"credential_process": schema.StringAttribute{
+				Optional:    true,
+				Description: "Command run through the shell when the provider is configured, e.g. to read the keys from a secrets manager. It must print a JSON object with any of host, admin_api_key, organization_public_key, organization_private_key, project_public_key and project_secret_key, which are used where the configuration sets none, ahead of the environment and the credentials profile. It runs once per command and Terraform run. Can also come from LANGFUSE_CREDENTIAL_PROCESS.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Profile of the Langfuse credentials file (~/.config/langfuse/credentials, or LANGFUSE_CREDENTIALS_FILE) providing the host and the keys that are set neither here nor in the environment. The file is only read when a profile or LANGFUSE_CREDENTIALS_FILE is set; with only the path, its default profile is used when it has one. Can also come from LANGFUSE_PROFILE.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"admin_api_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "Admin API key. Only needed when managing organizations. Can also come from LANGFUSE_ADMIN_KEY.",
+				Description: "Admin API key. Only needed when managing organizations and their API keys, which fail to plan without it; the admin API is only available on self-hosted instances. Can also come from LANGFUSE_ADMIN_KEY.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of retries for requests that fail with a rate limit (429), a server error (5xx) or a network error. POST requests are only retried on 429. Set to 0 to disable retries (defaults to 3).",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of seconds to wait between two retries, including waits requested by the server through Retry-After (defaults to 30).",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout in seconds for a single attempt of a request. Set to 0 to disable the timeout (defaults to 60). Can also come from LANGFUSE_REQUEST_TIMEOUT.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests per second sent with one set of credentials, shared by all resources using them. Set to 0 for no limit (the default).",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests in flight at the same time for one set of credentials, shared by all resources using them. Set to 0 for no limit (the default).",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM-encoded certificate authority bundle trusted in addition to the system roots. Can also come from LANGFUSE_CA_CERT_FILE.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM-encoded certificate authority bundle trusted in addition to the system roots. Can also come from LANGFUSE_CA_CERT_PEM.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM-encoded client certificate, or a path to it, used for mutual TLS. Requires client_key. Can also come from LANGFUSE_CLIENT_CERT.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded client private key, or a path to it, used for mutual TLS. Requires client_cert. Can also come from LANGFUSE_CLIENT_KEY.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verification of the server's TLS certificate. Only use this for testing. Can also come from LANGFUSE_INSECURE_SKIP_VERIFY.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy used to reach the Langfuse instance. Defaults to the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. Can also come from LANGFUSE_PROXY_URL.",
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip checking that the host is reachable and that it accepts the admin API key when the provider is configured, e.g. to plan offline. Can also come from LANGFUSE_SKIP_CREDENTIALS_VALIDATION.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refuse every request that could change Langfuse, such as creating, updating or deleting objects, before it is sent. Refreshes and imports keep working, e.g. for drift checks and audits. Can also come from LANGFUSE_READ_ONLY.",
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text appended to the User-Agent header of every request, e.g. to identify a CI pipeline. Can also come from LANGFUSE_USER_AGENT_SUFFIX.",
+			},
+			"default_metadata": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Metadata merged into the metadata of every langfuse_organization and langfuse_project, whose own metadata takes precedence. Keys coming only from here appear in their metadata_all attribute, not in metadata.",
+			},
+			"default_deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Deletion protection of the langfuse_organization and langfuse_project resources that do not set deletion_protection (defaults to false).",
+			},
+			"credentials": schema.MapNestedAttribute{
+				Optional:    true,
+				Description: "Named sets of organization and project API keys, selected by resources through their credentials attribute, so that one provider can manage many organizations and projects.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"organization_public_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Organization public key. Requires organization_private_key.",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("organization_private_key")),
+							},
+						},
+						"organization_private_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Organization private key. Requires organization_public_key.",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("organization_public_key")),
+							},
+						},
+						"project_public_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Project public key. Requires project_secret_key.",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("project_secret_key")),
+							},
+						},
+						"project_secret_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Project secret key. Requires project_public_key.",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("project_public_key")),
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"organization": schema.SingleNestedBlock{
+				Description: "Organization API key used by langfuse_project, langfuse_project_api_key, langfuse_organization_membership and langfuse_project_membership resources that do not set organization_public_key and organization_private_key. Takes precedence over LANGFUSE_ORGANIZATION_PUBLIC_KEY and LANGFUSE_ORGANIZATION_SECRET_KEY.",
+				Attributes: map[string]schema.Attribute{
+					"public_key": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "Organization public key.",
+					},
+					"private_key": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "Organization private key.",
+					},
+				},
+			},
+			"project": schema.SingleNestedBlock{
+				Description: "Project API key used by langfuse_llm_connection resources that do not set project_public_key and project_secret_key. Takes precedence over LANGFUSE_PUBLIC_KEY and LANGFUSE_SECRET_KEY.",
+				Attributes: map[string]schema.Attribute{
+					"public_key": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "Project public key.",
+					},
+					"secret_key": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "Project secret key.",
+					},
+				},
 			},
 		},
 	}
@@ -52,17 +291,156 @@ func (p *langfuseProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	host := "https://app.langfuse.com"
-	if !config.Host.IsNull() && !config.Host.IsUnknown() && config.Host.ValueString() != "" {
-		host = config.Host.ValueString()
+	// The credentials profile only provides the settings that are set neither in the configuration nor in
+	// the environment.
+	var profile credentialsProfile
+	if profileName := stringValueOrEnv(config.Profile, "LANGFUSE_PROFILE"); credentialsFileSelected(profileName) {
+		var err error
+		profile, err = loadCredentialsProfile(credentialsFilePath(), profileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "Unable to load the credentials profile", err.Error())
+			return
+		}
+	}
+
+	// The output of the credential process counts as configuration, ahead of the environment.
+	var external credentialsProfile
+	if command := stringValueOrEnv(config.CredentialProcess, "LANGFUSE_CREDENTIAL_PROCESS"); command != "" {
+		var err error
+		external, err = credentialProcesses.run(ctx, command)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("credential_process"), "Unable to run the credential process", err.Error())
+			return
+		}
+	}
+
+	host := cmp.Or(config.Host.ValueString(), external.Host, os.Getenv("LANGFUSE_HOST"), profile.Host, defaultHost)
+
+	apiKey := cmp.Or(config.AdminAPIKey.ValueString(), external.AdminAPIKey, os.Getenv("LANGFUSE_ADMIN_KEY"), profile.AdminAPIKey)
+
+	// Resources without keys of their own fall back to these, after their own attributes. The organization
+	// and project blocks take precedence over the credential process, then the environment, then the profile.
+	var organizationCredentials langfuse.Credentials
+	if config.Organization != nil {
+		organizationCredentials = configuredCredentials(config.Organization.PublicKey, config.Organization.PrivateKey)
+	} else if !external.Organization.IsZero() {
+		organizationCredentials = external.Organization
+	} else {
+		var err error
+		organizationCredentials, err = credentialsFromEnv("LANGFUSE_ORGANIZATION_PUBLIC_KEY", "LANGFUSE_ORGANIZATION_SECRET_KEY")
+		if err != nil {
+			resp.Diagnostics.AddError("Incomplete organization credentials", err.Error())
+		}
+		if organizationCredentials.IsZero() {
+			organizationCredentials = profile.Organization
+		}
+	}
+	var projectCredentials langfuse.Credentials
+	if config.Project != nil {
+		projectCredentials = configuredCredentials(config.Project.PublicKey, config.Project.SecretKey)
+	} else if !external.Project.IsZero() {
+		projectCredentials = external.Project
+	} else {
+		var err error
+		projectCredentials, err = credentialsFromEnv("LANGFUSE_PUBLIC_KEY", "LANGFUSE_SECRET_KEY")
+		if err != nil {
+			resp.Diagnostics.AddError("Incomplete project credentials", err.Error())
+		}
+		if projectCredentials.IsZero() {
+			projectCredentials = profile.Project
+		}
+	}
+
+	credentialSets, diags := credentialSetsFromConfig(ctx, config.Credentials)
+	resp.Diagnostics.Append(diags...)
+
+	defaultMetadata, diags := metadataElements(ctx, config.DefaultMetadata)
+	resp.Diagnostics.Append(diags...)
+
+	retryConfig := langfuse.DefaultRetryConfig()
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		retryConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMaxWait.IsNull() && !config.RetryMaxWait.IsUnknown() {
+		retryConfig.MaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	var rateLimit langfuse.RateLimitConfig
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		rateLimit.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		rateLimit.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
 	}
 
-	apiKey := os.Getenv("LANGFUSE_ADMIN_KEY")
-	if !config.AdminAPIKey.IsNull() && !config.AdminAPIKey.IsUnknown() && config.AdminAPIKey.ValueString() != "" {
-		apiKey = config.AdminAPIKey.ValueString()
+	requestTimeout := langfuse.DefaultRequestTimeout
+	if seconds, ok, err := int64ValueOrEnv(config.RequestTimeout, "LANGFUSE_REQUEST_TIMEOUT"); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid request timeout", err.Error())
+	} else if ok {
+		requestTimeout = time.Duration(seconds) * time.Second
+	}
+
+	insecureSkipVerify, err := boolValueOrEnv(config.InsecureSkipVerify, "LANGFUSE_INSECURE_SKIP_VERIFY")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("insecure_skip_verify"), "Invalid insecure_skip_verify value", err.Error())
+	}
+	skipValidation, err := boolValueOrEnv(config.SkipCredentialsValidation, "LANGFUSE_SKIP_CREDENTIALS_VALIDATION")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("skip_credentials_validation"), "Invalid skip_credentials_validation value", err.Error())
+	}
+	readOnly, err := boolValueOrEnv(config.ReadOnly, "LANGFUSE_READ_ONLY")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("read_only"), "Invalid read_only value", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transport, err := langfuse.NewTransport(langfuse.TransportConfig{
+		CACertFile:         stringValueOrEnv(config.CACertFile, "LANGFUSE_CA_CERT_FILE"),
+		CACertPEM:          stringValueOrEnv(config.CACertPEM, "LANGFUSE_CA_CERT_PEM"),
+		ClientCert:         stringValueOrEnv(config.ClientCert, "LANGFUSE_CLIENT_CERT"),
+		ClientKey:          stringValueOrEnv(config.ClientKey, "LANGFUSE_CLIENT_KEY"),
+		InsecureSkipVerify: insecureSkipVerify,
+		ProxyURL:           stringValueOrEnv(config.ProxyURL, "LANGFUSE_PROXY_URL"),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid TLS or proxy configuration", err.Error())
+		return
+	}
+
+	clientOptions := append([]langfuse.ClientFactoryOption{
+		langfuse.WithTransport(transport),
+		langfuse.WithTimeout(requestTimeout),
+		langfuse.WithRetryConfig(retryConfig),
+		langfuse.WithRateLimit(rateLimit),
+		langfuse.WithDefaultOrganizationCredentials(organizationCredentials),
+		langfuse.WithDefaultProjectCredentials(projectCredentials),
+		langfuse.WithCredentialSets(credentialSets),
+		langfuse.WithDefaultMetadata(defaultMetadata),
+		langfuse.WithDefaultDeletionProtection(config.DefaultDeletionProtection.ValueBool()),
+		langfuse.WithReadOnly(readOnly),
+		langfuse.WithUserAgent(buildUserAgent(p.version, req.TerraformVersion, stringValueOrEnv(config.UserAgentSuffix, "LANGFUSE_USER_AGENT_SUFFIX"))),
+	}, p.clientOptions...)
+	if config.Credentials.IsUnknown() {
+		clientOptions = append(clientOptions, langfuse.WithUnknownCredentialSets())
+	}
+	if adminAPIKeyUnknown(config, apiKey) {
+		clientOptions = append(clientOptions, langfuse.WithUnknownAdminAPIKey())
+	}
+	clientFactory := langfuse.NewClientFactory(host, apiKey, clientOptions...)
+
+	if skipValidation {
+		tflog.Debug(ctx, "Skipping the validation of the Langfuse connection, as skip_credentials_validation is set")
+	} else if connectionSettingsUnknown(config) {
+		tflog.Info(ctx, "Skipping the validation of the Langfuse connection until its host and admin API key are known")
+	} else {
+		resp.Diagnostics.Append(validateConnection(ctx, clientFactory, host, apiKey != "")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	clientFactory := langfuse.NewClientFactory(host, apiKey)
 	resp.DataSourceData = clientFactory
 	resp.ResourceData = clientFactory
 }
@@ -88,3 +466,279 @@ func New(version string) func() provider.Provider {
 		return &langfuseProvider{version: version}
 	}
 }
+
+// connectionSettingsUnknown reports whether a setting the host or the admin API key can come from is not known
+// yet, such as a host taken from another resource while planning. Validating the connection would then check
+// the fallback host or key instead of the configured ones.
+func connectionSettingsUnknown(config langfuseProviderModel) bool {
+	return config.Host.IsUnknown() || config.AdminAPIKey.IsUnknown() ||
+		config.Profile.IsUnknown() || config.CredentialProcess.IsUnknown()
+}
+
+// adminAPIKeyUnknown reports whether the admin API key resolved to apiKey may still change once the settings
+// it can come from are known: the admin_api_key attribute, the credential process that takes precedence over
+// the environment, or the profile that only fills in a missing key.
+func adminAPIKeyUnknown(config langfuseProviderModel, apiKey string) bool {
+	if config.AdminAPIKey.IsUnknown() {
+		return true
+	}
+	if config.AdminAPIKey.ValueString() != "" {
+		return false
+	}
+	return config.CredentialProcess.IsUnknown() || (apiKey == "" && config.Profile.IsUnknown())
+}
+
+// validateConnection checks that the host is reachable and, when an admin API key is configured, that the
+// server accepts it, so that a typo surfaces while configuring the provider instead of in the middle of an apply.
+func validateConnection(ctx context.Context, clientFactory langfuse.ClientFactory, host string, hasAdminKey bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := clientFactory.Health(ctx); err != nil {
+		var apiErr *langfuse.APIError
+		if errors.As(err, &apiErr) {
+			diags.AddAttributeError(path.Root("host"), "Langfuse instance is not healthy",
+				fmt.Sprintf("The health check of %s failed: %s", host, err))
+		} else {
+			diags.AddAttributeError(path.Root("host"), "Unable to reach Langfuse",
+				fmt.Sprintf("Could not connect to %s: %s\n\nCheck the host, or set skip_credentials_validation to configure the provider without contacting the server.", host, err))
+		}
+		return diags
+	}
+
+	if !hasAdminKey {
+		return diags
+	}
+	if _, err := clientFactory.NewAdminClient().ListOrganizationsPage(ctx, 1, 1); err != nil {
+		if langfuse.IsUnauthorized(err) || langfuse.IsForbidden(err) {
+			diags.AddAttributeError(path.Root("admin_api_key"), "Invalid admin API key",
+				fmt.Sprintf("admin_api_key rejected by %s: %s", host, err))
+		} else {
+			diags.AddAttributeError(path.Root("admin_api_key"), "Unable to validate admin API key",
+				fmt.Sprintf("Calling the admin API of %s failed: %s", host, err))
+		}
+	}
+
+	return diags
+}
+
+// checkServerRequirement fails the plan of resourceType when the deployment is known not to serve it, instead
+// of letting the apply break partway through. Nothing is reported for destroy plans.
+func checkServerRequirement(clientFactory langfuse.ClientFactory, plan tfsdk.Plan, resourceType string, requirement langfuse.Requirement) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if clientFactory == nil || plan.Raw.IsNull() {
+		return diags
+	}
+
+	if err := clientFactory.ServerInfo().Check(requirement); err != nil {
+		diags.AddError("Unsupported Langfuse server", fmt.Sprintf("%s %s", resourceType, err))
+	}
+
+	return diags
+}
+
+// checkAdminAPIKey fails the plan of resourceType, which is served by the admin API, when the provider has no
+// admin API key, instead of letting the apply be rejected with a 401. Nothing is reported for destroy plans,
+// like for the other credentials, nor while the admin API key is not known yet.
+func checkAdminAPIKey(clientFactory langfuse.ClientFactory, plan tfsdk.Plan, resourceType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if clientFactory == nil || plan.Raw.IsNull() || clientFactory.HasAdminAPIKey() || clientFactory.AdminAPIKeyUnknown() {
+		return diags
+	}
+
+	diags.AddError("Missing admin API key",
+		fmt.Sprintf("%s is managed through the admin API, which needs an admin API key. Set admin_api_key in the provider, the LANGFUSE_ADMIN_KEY environment variable, the credential process or the credentials profile.\n\n"+
+			"The admin API is only available on self-hosted Langfuse instances, with ADMIN_API_KEY set on the server; Langfuse Cloud does not offer it.", resourceType))
+	return diags
+}
+
+// checkOrganizationCredentials fails the plan of resourceType when it sets only one of its organization keys,
+// names a credential set without organization keys, or has no keys at all while the provider has no default
+// organization credentials, instead of letting every request of the apply be rejected. Values that are not
+// known yet are assumed to be valid.
+func checkOrganizationCredentials(ctx context.Context, clientFactory langfuse.ClientFactory, plan tfsdk.Plan, resourceType string) diag.Diagnostics {
+	if clientFactory == nil {
+		return nil
+	}
+	return checkCredentials(ctx, clientFactory, plan, resourceType, credentialsScope{
+		name:               "organization",
+		publicKeyAttribute: "organization_public_key",
+		secretKeyAttribute: "organization_private_key",
+		publicKeyEnvVar:    "LANGFUSE_ORGANIZATION_PUBLIC_KEY",
+		secretKeyEnvVar:    "LANGFUSE_ORGANIZATION_SECRET_KEY",
+		defaults:           clientFactory.DefaultOrganizationCredentials(),
+		fromSet:            func(set langfuse.CredentialSet) langfuse.Credentials { return set.Organization },
+	})
+}
+
+// checkProjectCredentials is checkOrganizationCredentials for the resources authenticated with a project API key.
+func checkProjectCredentials(ctx context.Context, clientFactory langfuse.ClientFactory, plan tfsdk.Plan, resourceType string) diag.Diagnostics {
+	if clientFactory == nil {
+		return nil
+	}
+	return checkCredentials(ctx, clientFactory, plan, resourceType, credentialsScope{
+		name:               "project",
+		publicKeyAttribute: "project_public_key",
+		secretKeyAttribute: "project_secret_key",
+		publicKeyEnvVar:    "LANGFUSE_PUBLIC_KEY",
+		secretKeyEnvVar:    "LANGFUSE_SECRET_KEY",
+		defaults:           clientFactory.DefaultProjectCredentials(),
+		fromSet:            func(set langfuse.CredentialSet) langfuse.Credentials { return set.Project },
+	})
+}
+
+// credentialsScope describes where the organization or project keys of a resource can come from.
+type credentialsScope struct {
+	name                                   string
+	publicKeyAttribute, secretKeyAttribute string
+	publicKeyEnvVar, secretKeyEnvVar       string
+	defaults                               langfuse.Credentials
+	fromSet                                func(langfuse.CredentialSet) langfuse.Credentials
+}
+
+func checkCredentials(ctx context.Context, clientFactory langfuse.ClientFactory, plan tfsdk.Plan, resourceType string, scope credentialsScope) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Raw.IsNull() {
+		return diags
+	}
+
+	var credentialSet, publicKey, secretKey types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("credentials"), &credentialSet)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root(scope.publicKeyAttribute), &publicKey)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root(scope.secretKeyAttribute), &secretKey)...)
+	if diags.HasError() || credentialSet.IsUnknown() || publicKey.IsUnknown() || secretKey.IsUnknown() {
+		return diags
+	}
+
+	switch {
+	case publicKey.ValueString() != "" && secretKey.ValueString() != "":
+	case publicKey.ValueString() != "" || secretKey.ValueString() != "":
+		diags.AddError(fmt.Sprintf("Incomplete %s credentials", scope.name),
+			fmt.Sprintf("%s must set %s and %s together.", resourceType, scope.publicKeyAttribute, scope.secretKeyAttribute))
+	case credentialSet.ValueString() != "":
+		set, ok := clientFactory.CredentialSet(credentialSet.ValueString())
+		if !ok {
+			diags.AddAttributeError(path.Root("credentials"), "Unknown credential set",
+				fmt.Sprintf("%s uses the credential set %q, which is not in the credentials of the provider.", resourceType, credentialSet.ValueString()))
+		} else if scope.fromSet(set).IsZero() {
+			diags.AddAttributeError(path.Root("credentials"), fmt.Sprintf("Missing %s credentials", scope.name),
+				fmt.Sprintf("The credential set %q used by %s has no %s API key. Set its %s and %s.",
+					credentialSet.ValueString(), resourceType, scope.name, scope.publicKeyAttribute, scope.secretKeyAttribute))
+		}
+	case scope.defaults.IsZero():
+		diags.AddError(fmt.Sprintf("Missing %s credentials", scope.name),
+			fmt.Sprintf("%s has no %s API key. Set %s and %s, credentials, the %s block of the provider, the %s and %s environment variables, or the credentials profile.",
+				resourceType, scope.name, scope.publicKeyAttribute, scope.secretKeyAttribute, scope.name, scope.publicKeyEnvVar, scope.secretKeyEnvVar))
+	}
+
+	return diags
+}
+
+// buildUserAgent identifies the provider and Terraform versions to the Langfuse server, so that Terraform
+// traffic can be told apart from SDK traffic in its logs.
+func buildUserAgent(providerVersion, terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("%s/%s", langfuse.DefaultUserAgent, providerVersion)
+	if terraformVersion != "" {
+		userAgent += " terraform/" + terraformVersion
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// stringValueOrEnv returns the configured value, falling back to the environment variable when it is not set.
+func stringValueOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(envVar)
+}
+
+// credentialSetsFromConfig converts the credentials map of the provider. Sets and keys that are not known yet,
+// such as those of API keys created in the same apply, are marked unknown, so that the plans of the resources
+// using them pass and the keys are used once known. A map that is not known as a whole is left to
+// WithUnknownCredentialSets.
+func credentialSetsFromConfig(ctx context.Context, value types.Map) (map[string]langfuse.CredentialSet, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	sets := make(map[string]langfuse.CredentialSet, len(value.Elements()))
+	for name, element := range value.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			diags.AddError("Invalid credential set", fmt.Sprintf("The credential set %q is a %T, not an object.", name, element))
+			continue
+		}
+		if object.IsUnknown() {
+			sets[name] = langfuse.CredentialSet{Organization: langfuse.Credentials{Unknown: true}, Project: langfuse.Credentials{Unknown: true}}
+			continue
+		}
+
+		var model credentialSetModel
+		diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+		sets[name] = langfuse.CredentialSet{
+			Organization: configuredCredentials(model.OrganizationPublicKey, model.OrganizationPrivateKey),
+			Project:      configuredCredentials(model.ProjectPublicKey, model.ProjectSecretKey),
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return sets, diags
+}
+
+// configuredCredentials converts a pair of keys of the provider configuration, from its organization or
+// project block or from a credential set. Keys that are not known yet, such as those of an API key created in
+// the same apply, are marked unknown, so that plans do not report them as missing.
+func configuredCredentials(publicKey, secretKey types.String) langfuse.Credentials {
+	return langfuse.Credentials{
+		PublicKey: publicKey.ValueString(),
+		SecretKey: secretKey.ValueString(),
+		Unknown:   publicKey.IsUnknown() || secretKey.IsUnknown(),
+	}
+}
+
+// credentialsFromEnv reads a pair of API keys from the environment. Both keys must be set, or neither.
+func credentialsFromEnv(publicKeyEnvVar, secretKeyEnvVar string) (langfuse.Credentials, error) {
+	credentials := langfuse.Credentials{
+		PublicKey: os.Getenv(publicKeyEnvVar),
+		SecretKey: os.Getenv(secretKeyEnvVar),
+	}
+	if (credentials.PublicKey == "") != (credentials.SecretKey == "") {
+		return langfuse.Credentials{}, fmt.Errorf("%s and %s must be set together", publicKeyEnvVar, secretKeyEnvVar)
+	}
+	return credentials, nil
+}
+
+func boolValueOrEnv(value types.Bool, envVar string) (bool, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool(), nil
+	}
+	env := os.Getenv(envVar)
+	if env == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(env)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q", envVar, env)
+	}
+	return parsed, nil
+}
+
+// int64ValueOrEnv reports whether a value was found in the configuration or in the environment variable.
+func int64ValueOrEnv(value types.Int64, envVar string) (int64, bool, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64(), true, nil
+	}
+	env := os.Getenv(envVar)
+	if env == "" {
+		return 0, false, nil
+	}
+	parsed, err := strconv.ParseInt(env, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, false, fmt.Errorf("%s must be a non-negative integer, got %q", envVar, env)
+	}
+	return parsed, true, nil
+}
@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -13,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
 )
 
 // TestAccLangfuseWorkflow tests the complete workflow of creating and managing
@@ -26,11 +29,11 @@ func TestAccLangfuseWorkflow(t *testing.T) {
 	testAccPreCheck(t)
 
 	// Generate unique names for this test run
-	orgName := fmt.Sprintf("test-org-%d", rand.Intn(1000000))
-	projectName := fmt.Sprintf("test-project-%d", rand.Intn(1000000))
+	orgName := testAccName(t, "test-org")
+	projectName := testAccName(t, "test-project")
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		CheckDestroy:             testAccCheckLangfuseResourcesDestroyed,
 		Steps: []resource.TestStep{
 			// Step 1: Create Organization with metadata
@@ -340,11 +343,11 @@ func TestAccLangfuseOrganizationImport(t *testing.T) {
 	testAccPreCheck(t)
 
 	// Generate unique names for this test run
-	orgName := fmt.Sprintf("import-test-org-%d", rand.Intn(1000000))
-	projectName := fmt.Sprintf("import-test-project-%d", rand.Intn(1000000))
+	orgName := testAccName(t, "import-test-org")
+	projectName := testAccName(t, "import-test-project")
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		CheckDestroy:             testAccCheckLangfuseResourcesDestroyed,
 		Steps: []resource.TestStep{
 			// Step 1: Create organization and project normally
@@ -508,11 +511,11 @@ func TestAccLangfuseProjectApiKeyNoteReplace(t *testing.T) {
 
 	testAccPreCheck(t)
 
-	orgName := fmt.Sprintf("note-replace-org-%d", rand.Intn(1000000))
-	projectName := fmt.Sprintf("note-replace-proj-%d", rand.Intn(1000000))
+	orgName := testAccName(t, "note-replace-org")
+	projectName := testAccName(t, "note-replace-proj")
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		CheckDestroy:             testAccCheckLangfuseResourcesDestroyed,
 		Steps: []resource.TestStep{
 			{
@@ -579,11 +582,68 @@ resource "langfuse_project_api_key" "note_test" {
 `, host, adminKey, orgName, projectName, projectKeyNote)
 }
 
-var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"langfuse": providerserver.NewProtocol6WithError(New("test")()),
+// testAccProtoV6ProviderFactories serves a provider whose exchanges with Langfuse go through a recorder set
+// by LANGFUSE_RECORDER_MODE. In record mode they are saved to testdata/cassettes/<test name>.json, in replay
+// mode they are answered from that cassette, so that the test runs without Docker or a license key. A test
+// without a cassette fails in replay mode, so that a replayed run never passes without exercising anything.
+func testAccProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	mode := testAccRecorderMode(t)
+	recorder, err := langfuse.NewRecorder(mode, filepath.Join("testdata", "cassettes", t.Name()+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("no cassette recorded for %s, run it with %s=%s first", t.Name(), langfuse.RecorderModeEnvVar, langfuse.RecorderModeRecord)
+	}
+	if err != nil {
+		t.Fatalf("creating recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("saving cassette: %v", err)
+		}
+	})
+
+	p := &langfuseProvider{
+		version:       "test",
+		clientOptions: []langfuse.ClientFactoryOption{langfuse.WithMiddleware(recorder.Middleware)},
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"langfuse": providerserver.NewProtocol6WithError(p),
+	}
+}
+
+func testAccRecorderMode(t *testing.T) langfuse.RecorderMode {
+	t.Helper()
+
+	mode, err := langfuse.ParseRecorderMode(os.Getenv(langfuse.RecorderModeEnvVar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mode
+}
+
+// testAccName returns a unique name for a live run. Names are fixed when recording or replaying, since
+// they are part of the recorded requests.
+func testAccName(t *testing.T, prefix string) string {
+	t.Helper()
+
+	if testAccRecorderMode(t) != langfuse.RecorderModeLive {
+		return prefix + "-recorded"
+	}
+	return fmt.Sprintf("%s-%d", prefix, rand.Intn(1000000))
 }
 
 func testAccPreCheck(t *testing.T) {
+	// Replayed tests never reach the host, so any placeholder credentials will do.
+	if testAccRecorderMode(t) == langfuse.RecorderModeReplay {
+		if os.Getenv("LANGFUSE_HOST") == "" {
+			t.Setenv("LANGFUSE_HOST", "http://localhost:3000")
+		}
+		if os.Getenv("LANGFUSE_ADMIN_KEY") == "" {
+			t.Setenv("LANGFUSE_ADMIN_KEY", "replayed-admin-key")
+		}
+	}
+
 	if v := os.Getenv("LANGFUSE_HOST"); v == "" {
 		t.Fatal("LANGFUSE_HOST must be set for acceptance tests")
 	}
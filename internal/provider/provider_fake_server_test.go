@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse/langfusetest"
+)
+
+// TestFakeServerWorkflow applies real configurations of every resource against the in-memory Langfuse of
+// langfusetest, so that the whole provider is exercised end to end without Docker. It only needs Terraform.
+func TestFakeServerWorkflow(t *testing.T) {
+	server := testFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testFakeServerProviderFactories(),
+		CheckDestroy:             testFakeServerCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeServerWorkflowConfig(server, "web", "MEMBER", "gpt-4o"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("langfuse_organization.test", "name", "acme"),
+					resource.TestCheckResourceAttr("langfuse_organization.test", "metadata.team", "platform"),
+					resource.TestCheckResourceAttrSet("langfuse_organization_api_key.test", "secret_key"),
+					resource.TestCheckResourceAttr("langfuse_project.test", "name", "web"),
+					resource.TestCheckResourceAttr("langfuse_project.test", "retention_days", "30"),
+					resource.TestCheckResourceAttr("langfuse_project_api_key.test", "note", "llm"),
+					resource.TestCheckResourceAttr("langfuse_organization_membership.test", "role", "MEMBER"),
+					resource.TestCheckResourceAttrPair("langfuse_organization_membership.test", "id", "langfuse_organization_membership.test", "user_id"),
+					resource.TestCheckResourceAttr("langfuse_project_membership.test", "role", "VIEWER"),
+					resource.TestCheckResourceAttrPair("langfuse_project_membership.test", "user_id", "langfuse_organization_membership.test", "user_id"),
+					resource.TestCheckResourceAttr("langfuse_llm_connection.test", "custom_models.0", "gpt-4o"),
+					resource.TestCheckResourceAttr("langfuse_llm_connection.test", "with_default_models", "false"),
+				),
+			},
+			{
+				Config: testFakeServerWorkflowConfig(server, "web-renamed", "ADMIN", "gpt-4.1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("langfuse_project.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("langfuse_organization_membership.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("langfuse_llm_connection.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("langfuse_project_api_key.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("langfuse_project.test", "name", "web-renamed"),
+					resource.TestCheckResourceAttr("langfuse_organization_membership.test", "role", "ADMIN"),
+					resource.TestCheckResourceAttr("langfuse_llm_connection.test", "custom_models.0", "gpt-4.1"),
+				),
+			},
+			{
+				ResourceName:            "langfuse_organization.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+// testFakeServer starts an in-memory Langfuse, skipping the test when Terraform is not installed, since
+// terraform-plugin-testing would otherwise try to download it. In CI, which installs Terraform, the test fails
+// instead, so that it never stops running unnoticed.
+func testFakeServer(t *testing.T) *langfusetest.Server {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			if os.Getenv("CI") != "" {
+				t.Fatal("terraform not found in PATH and TF_ACC_TERRAFORM_PATH not set, but the fake server test must run in CI")
+			}
+			t.Skip("terraform not found in PATH and TF_ACC_TERRAFORM_PATH not set - skipping fake server test")
+		}
+	}
+
+	return langfusetest.NewServer(t)
+}
+
+func testFakeServerProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"langfuse": providerserver.NewProtocol6WithError(New("test")()),
+	}
+}
+
+// testFakeServerCheckDestroyed checks that the destroyed resources are gone from the fake server. Memberships
+// are identified by their user, which outlives them, and are not checked.
+func testFakeServerCheckDestroyed(server *langfusetest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, rs := range s.RootModule().Resources {
+			switch rs.Type {
+			case "langfuse_organization_membership", "langfuse_project_membership":
+				continue
+			}
+			if server.Exists(rs.Primary.ID) {
+				return fmt.Errorf("%s still exists on the server", name)
+			}
+		}
+		return nil
+	}
+}
+
+func testFakeServerWorkflowConfig(server *langfusetest.Server, projectName, role, model string) string {
+	return fmt.Sprintf(`
+provider "langfuse" {
+  host          = %[1]q
+  admin_api_key = %[2]q
+}
+
+resource "langfuse_organization" "test" {
+  name = "acme"
+  metadata = {
+    team = "platform"
+  }
+}
+
+resource "langfuse_organization_api_key" "test" {
+  organization_id = langfuse_organization.test.id
+}
+
+resource "langfuse_project" "test" {
+  name                     = %[3]q
+  retention_days           = 30
+  organization_id          = langfuse_organization.test.id
+  organization_public_key  = langfuse_organization_api_key.test.public_key
+  organization_private_key = langfuse_organization_api_key.test.secret_key
+}
+
+resource "langfuse_project_api_key" "test" {
+  project_id               = langfuse_project.test.id
+  organization_public_key  = langfuse_organization_api_key.test.public_key
+  organization_private_key = langfuse_organization_api_key.test.secret_key
+  note                     = "llm"
+}
+
+resource "langfuse_organization_membership" "test" {
+  email                    = "jane@example.com"
+  role                     = %[4]q
+  organization_public_key  = langfuse_organization_api_key.test.public_key
+  organization_private_key = langfuse_organization_api_key.test.secret_key
+}
+
+resource "langfuse_project_membership" "test" {
+  project_id               = langfuse_project.test.id
+  email                    = langfuse_organization_membership.test.email
+  role                     = "VIEWER"
+  organization_public_key  = langfuse_organization_api_key.test.public_key
+  organization_private_key = langfuse_organization_api_key.test.secret_key
+}
+
+resource "langfuse_llm_connection" "test" {
+  project_public_key  = langfuse_project_api_key.test.public_key
+  project_secret_key  = langfuse_project_api_key.test.secret_key
+  provider_name       = "openai"
+  adapter             = "openai"
+  secret_key          = "sk-provider-secret"
+  custom_models       = [%[5]q]
+  with_default_models = false
+}
+`, server.URL, server.AdminKey, projectName, role, model)
+}
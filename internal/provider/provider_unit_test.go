@@ -0,0 +1,519 @@
+package provider
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/langfuse/terraform-provider-langfuse/internal/langfuse"
+	"go.uber.org/mock/gomock"
+)
+
+// seqOf returns an iterator over items, as returned by the List methods of the mocked clients.
+func seqOf[T any](items []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// seqError returns an iterator failing with err, as returned by the List methods when a request fails.
+func seqError[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+func TestBuildUserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		providerVersion  string
+		terraformVersion string
+		suffix           string
+		expected         string
+	}{
+		{
+			name:             "provider and terraform versions",
+			providerVersion:  "0.2.0",
+			terraformVersion: "1.9.5",
+			expected:         "terraform-provider-langfuse/0.2.0 terraform/1.9.5",
+		},
+		{
+			name:             "with suffix",
+			providerVersion:  "0.2.0",
+			terraformVersion: "1.9.5",
+			suffix:           " pipeline/4711 ",
+			expected:         "terraform-provider-langfuse/0.2.0 terraform/1.9.5 pipeline/4711",
+		},
+		{
+			name:            "unknown terraform version",
+			providerVersion: "dev",
+			expected:        "terraform-provider-langfuse/dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := buildUserAgent(tt.providerVersion, tt.terraformVersion, tt.suffix); got != tt.expected {
+				t.Fatalf("unexpected user agent: got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateConnection(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/health":
+			_, _ = w.Write([]byte(`{"status":"OK","version":"3.100.0"}`))
+		case "/api/admin/organizations":
+			if r.Header.Get("Authorization") != "Bearer valid-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Invalid admin API key"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"organizations":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	tests := []struct {
+		name        string
+		host        string
+		adminApiKey string
+		errorPath   *path.Path
+	}{
+		{
+			name:        "valid admin key",
+			host:        server.URL,
+			adminApiKey: "valid-key",
+		},
+		{
+			name: "no admin key only checks health",
+			host: server.URL,
+		},
+		{
+			name:        "rejected admin key",
+			host:        server.URL,
+			adminApiKey: "wrong-key",
+			errorPath:   pathPointer(path.Root("admin_api_key")),
+		},
+		{
+			name:        "unreachable host",
+			host:        unreachable.URL,
+			adminApiKey: "valid-key",
+			errorPath:   pathPointer(path.Root("host")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := langfuse.NewClientFactory(tt.host, tt.adminApiKey, langfuse.WithRetryConfig(langfuse.RetryConfig{}))
+			diags := validateConnection(context.Background(), factory, tt.host, tt.adminApiKey != "")
+
+			if tt.errorPath == nil {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(interface{ Path() path.Path })
+			if !ok || !withPath.Path().Equal(*tt.errorPath) {
+				t.Fatalf("expected an error on %s, got %v", tt.errorPath, diags)
+			}
+		})
+	}
+}
+
+func TestConnectionSettingsUnknown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   langfuseProviderModel
+		expected bool
+	}{
+		{name: "known", config: langfuseProviderModel{Host: types.StringValue("https://langfuse.example.com"), AdminAPIKey: types.StringValue("admin-key")}},
+		{name: "unset", config: langfuseProviderModel{}},
+		{name: "unknown host", config: langfuseProviderModel{Host: types.StringUnknown()}, expected: true},
+		{name: "unknown admin key", config: langfuseProviderModel{AdminAPIKey: types.StringUnknown()}, expected: true},
+		{name: "unknown profile", config: langfuseProviderModel{Profile: types.StringUnknown()}, expected: true},
+		{name: "unknown credential process", config: langfuseProviderModel{CredentialProcess: types.StringUnknown()}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := connectionSettingsUnknown(tt.config); got != tt.expected {
+				t.Fatalf("connectionSettingsUnknown() = %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAdminAPIKeyUnknown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   langfuseProviderModel
+		apiKey   string
+		expected bool
+	}{
+		{name: "configured", config: langfuseProviderModel{AdminAPIKey: types.StringValue("admin-key")}, apiKey: "admin-key"},
+		{name: "unset", config: langfuseProviderModel{}},
+		{name: "unknown", config: langfuseProviderModel{AdminAPIKey: types.StringUnknown()}, expected: true},
+		{name: "unknown credential process", config: langfuseProviderModel{CredentialProcess: types.StringUnknown()}, apiKey: "env-key", expected: true},
+		{name: "configured with unknown credential process", config: langfuseProviderModel{AdminAPIKey: types.StringValue("admin-key"), CredentialProcess: types.StringUnknown()}, apiKey: "admin-key"},
+		{name: "unknown profile", config: langfuseProviderModel{Profile: types.StringUnknown()}, expected: true},
+		{name: "unknown profile after the environment", config: langfuseProviderModel{Profile: types.StringUnknown()}, apiKey: "env-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := adminAPIKeyUnknown(tt.config, tt.apiKey); got != tt.expected {
+				t.Fatalf("adminAPIKeyUnknown() = %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckServerRequirement(t *testing.T) {
+	t.Parallel()
+
+	plannedObject := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})}
+	destroyPlan := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, nil)}
+
+	tests := []struct {
+		name    string
+		host    string
+		plan    tfsdk.Plan
+		wantErr string
+	}{
+		{
+			name: "self-hosted",
+			host: "https://langfuse.example.com",
+			plan: plannedObject,
+		},
+		{
+			name:    "cloud",
+			host:    "https://cloud.langfuse.com",
+			plan:    plannedObject,
+			wantErr: "langfuse_organization requires a self-hosted Langfuse instance",
+		},
+		{
+			name: "destroy is never blocked",
+			host: "https://cloud.langfuse.com",
+			plan: destroyPlan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := langfuse.NewClientFactory(tt.host, "")
+			diags := checkServerRequirement(factory, tt.plan, "langfuse_organization", langfuse.RequireAdminAPI)
+
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestCheckAdminAPIKey(t *testing.T) {
+	t.Parallel()
+
+	plannedObject := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})}
+	destroyPlan := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, nil)}
+
+	tests := []struct {
+		name        string
+		adminAPIKey string
+		options     []langfuse.ClientFactoryOption
+		plan        tfsdk.Plan
+		wantErr     bool
+	}{
+		{name: "admin key", adminAPIKey: "admin-key", plan: plannedObject},
+		{name: "missing admin key", plan: plannedObject, wantErr: true},
+		{name: "admin key not known yet", options: []langfuse.ClientFactoryOption{langfuse.WithUnknownAdminAPIKey()}, plan: plannedObject},
+		{name: "destroy", plan: destroyPlan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := langfuse.NewClientFactory("http://localhost", tt.adminAPIKey, tt.options...)
+			diags := checkAdminAPIKey(factory, tt.plan, "langfuse_organization")
+
+			if !tt.wantErr {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Missing admin API key" ||
+				!strings.Contains(diags.Errors()[0].Detail(), "self-hosted") {
+				t.Fatalf("expected a missing admin API key error, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestCheckOrganizationCredentials(t *testing.T) {
+	t.Parallel()
+
+	planSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"credentials":              schema.StringAttribute{Optional: true},
+			"organization_public_key":  schema.StringAttribute{Optional: true},
+			"organization_private_key": schema.StringAttribute{Optional: true},
+		},
+	}
+	planOf := func(credentialSet, publicKey, privateKey any) tfsdk.Plan {
+		return tfsdk.Plan{
+			Schema: planSchema,
+			Raw: tftypes.NewValue(planSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+				"credentials":              tftypes.NewValue(tftypes.String, credentialSet),
+				"organization_public_key":  tftypes.NewValue(tftypes.String, publicKey),
+				"organization_private_key": tftypes.NewValue(tftypes.String, privateKey),
+			}),
+		}
+	}
+	defaults := langfuse.Credentials{PublicKey: "pk-default", SecretKey: "sk-default"}
+	credentialSets := map[string]langfuse.CredentialSet{
+		"team-a":       {Organization: langfuse.Credentials{PublicKey: "pk-a", SecretKey: "sk-a"}},
+		"project-only": {Project: langfuse.Credentials{PublicKey: "pk-p", SecretKey: "sk-p"}},
+		"pending":      {Organization: langfuse.Credentials{Unknown: true}},
+	}
+
+	tests := []struct {
+		name     string
+		plan     tfsdk.Plan
+		defaults langfuse.Credentials
+		wantErr  string
+	}{
+		{name: "resource keys", plan: planOf(nil, "pk", "sk")},
+		{name: "provider defaults", plan: planOf(nil, nil, nil), defaults: defaults},
+		{name: "provider defaults not known yet", plan: planOf(nil, nil, nil), defaults: langfuse.Credentials{Unknown: true}},
+		{name: "credential set", plan: planOf("team-a", nil, nil)},
+		{name: "credential set not known yet", plan: planOf("pending", nil, nil)},
+		{name: "unknown keys", plan: planOf(nil, tftypes.UnknownValue, tftypes.UnknownValue)},
+		{name: "unknown credential set name", plan: planOf(tftypes.UnknownValue, nil, nil)},
+		{name: "destroy", plan: tfsdk.Plan{Schema: planSchema, Raw: tftypes.NewValue(planSchema.Type().TerraformType(context.Background()), nil)}},
+		{name: "missing", plan: planOf(nil, nil, nil), wantErr: "Missing organization credentials"},
+		{name: "incomplete", plan: planOf(nil, "pk", nil), defaults: defaults, wantErr: "Incomplete organization credentials"},
+		{name: "undefined credential set", plan: planOf("team-b", nil, nil), defaults: defaults, wantErr: "Unknown credential set"},
+		{name: "credential set without organization keys", plan: planOf("project-only", nil, nil), defaults: defaults, wantErr: "Missing organization credentials"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := langfuse.NewClientFactory("http://localhost", "", langfuse.WithDefaultOrganizationCredentials(tt.defaults), langfuse.WithCredentialSets(credentialSets))
+			diags := checkOrganizationCredentials(context.Background(), factory, tt.plan, "langfuse_project")
+
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != tt.wantErr {
+				t.Fatalf("expected a %q error, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestCheckProjectCredentials(t *testing.T) {
+	t.Parallel()
+
+	planSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"credentials":        schema.StringAttribute{Optional: true},
+			"project_public_key": schema.StringAttribute{Optional: true},
+			"project_secret_key": schema.StringAttribute{Optional: true},
+		},
+	}
+	plan := tfsdk.Plan{
+		Schema: planSchema,
+		Raw: tftypes.NewValue(planSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+			"credentials":        tftypes.NewValue(tftypes.String, nil),
+			"project_public_key": tftypes.NewValue(tftypes.String, nil),
+			"project_secret_key": tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	withDefaults := langfuse.NewClientFactory("http://localhost", "", langfuse.WithDefaultProjectCredentials(langfuse.Credentials{PublicKey: "pk-default", SecretKey: "sk-default"}))
+	if diags := checkProjectCredentials(context.Background(), withDefaults, plan, "langfuse_llm_connection"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The keys of a project block fed from a project API key created in the same apply are not known yet.
+	withUnknownDefaults := langfuse.NewClientFactory("http://localhost", "", langfuse.WithDefaultProjectCredentials(langfuse.Credentials{Unknown: true}))
+	if diags := checkProjectCredentials(context.Background(), withUnknownDefaults, plan, "langfuse_llm_connection"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Default organization credentials do not stand in for project credentials.
+	withoutDefaults := langfuse.NewClientFactory("http://localhost", "", langfuse.WithDefaultOrganizationCredentials(langfuse.Credentials{PublicKey: "pk-org", SecretKey: "sk-org"}))
+	diags := checkProjectCredentials(context.Background(), withoutDefaults, plan, "langfuse_llm_connection")
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Missing project credentials" {
+		t.Fatalf("expected a missing credentials error, got %v", diags)
+	}
+}
+
+func TestCredentialSetsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	setType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"organization_public_key":  types.StringType,
+		"organization_private_key": types.StringType,
+		"project_public_key":       types.StringType,
+		"project_secret_key":       types.StringType,
+	}}
+	value := types.MapValueMust(setType, map[string]attr.Value{
+		"team-a": types.ObjectValueMust(setType.AttrTypes, map[string]attr.Value{
+			"organization_public_key":  types.StringValue("pk-org"),
+			"organization_private_key": types.StringValue("sk-org"),
+			"project_public_key":       types.StringNull(),
+			"project_secret_key":       types.StringNull(),
+		}),
+	})
+
+	sets, diags := credentialSetsFromConfig(ctx, value)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := langfuse.CredentialSet{Organization: langfuse.Credentials{PublicKey: "pk-org", SecretKey: "sk-org"}}
+	if len(sets) != 1 || sets["team-a"] != want {
+		t.Fatalf("credentialSetsFromConfig() = %+v", sets)
+	}
+
+	if sets, diags := credentialSetsFromConfig(ctx, types.MapNull(setType)); diags.HasError() || sets != nil {
+		t.Fatalf("expected no credential sets for a null map, got %+v, %v", sets, diags)
+	}
+
+	// Sets fed from API keys created in the same apply are not known while planning.
+	pending := types.MapValueMust(setType, map[string]attr.Value{
+		"new-org": types.ObjectUnknown(setType.AttrTypes),
+		"new-project": types.ObjectValueMust(setType.AttrTypes, map[string]attr.Value{
+			"organization_public_key":  types.StringNull(),
+			"organization_private_key": types.StringNull(),
+			"project_public_key":       types.StringUnknown(),
+			"project_secret_key":       types.StringUnknown(),
+		}),
+	})
+	sets, diags = credentialSetsFromConfig(ctx, pending)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if set := sets["new-org"]; !set.Organization.Unknown || !set.Project.Unknown {
+		t.Fatalf("expected an unknown set to have unknown keys, got %+v", set)
+	}
+	if set := sets["new-project"]; !set.Organization.IsZero() || !set.Project.Unknown {
+		t.Fatalf("expected only the project keys to be unknown, got %+v", set)
+	}
+}
+
+// contextWithDeadline matches the contexts that resource operations pass to the clients, which are bounded by
+// the resource's timeouts.
+func contextWithDeadline() gomock.Matcher {
+	return gomock.Cond(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})
+}
+
+// Types of the timeouts blocks, for the resources that can be updated in place and for those that are replaced.
+var (
+	crudTimeoutsType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"read":   tftypes.String,
+		"update": tftypes.String,
+		"delete": tftypes.String,
+	}}
+	createReadDeleteTimeoutsType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"read":   tftypes.String,
+		"delete": tftypes.String,
+	}}
+)
+
+func pathPointer(p path.Path) *path.Path {
+	return &p
+}
+
+func TestConfiguredCredentials(t *testing.T) {
+	t.Parallel()
+
+	if got := configuredCredentials(types.StringValue("pk"), types.StringValue("sk")); got != (langfuse.Credentials{PublicKey: "pk", SecretKey: "sk"}) {
+		t.Fatalf("configuredCredentials() = %+v", got)
+	}
+	if got := configuredCredentials(types.StringUnknown(), types.StringValue("sk")); !got.Unknown || got.IsZero() {
+		t.Fatalf("expected keys not known yet to be marked unknown, got %+v", got)
+	}
+}
+
+func TestCredentialsFromEnv(t *testing.T) {
+	t.Setenv("TEST_LANGFUSE_PUBLIC_KEY", "pk-env")
+	t.Setenv("TEST_LANGFUSE_SECRET_KEY", "sk-env")
+
+	credentials, err := credentialsFromEnv("TEST_LANGFUSE_PUBLIC_KEY", "TEST_LANGFUSE_SECRET_KEY")
+	if err != nil || credentials != (langfuse.Credentials{PublicKey: "pk-env", SecretKey: "sk-env"}) {
+		t.Fatalf("credentialsFromEnv() = %+v, %v", credentials, err)
+	}
+
+	t.Setenv("TEST_LANGFUSE_SECRET_KEY", "")
+	if _, err := credentialsFromEnv("TEST_LANGFUSE_PUBLIC_KEY", "TEST_LANGFUSE_SECRET_KEY"); err == nil {
+		t.Fatalf("expected an error when only the public key is set")
+	}
+
+	t.Setenv("TEST_LANGFUSE_PUBLIC_KEY", "")
+	if credentials, err := credentialsFromEnv("TEST_LANGFUSE_PUBLIC_KEY", "TEST_LANGFUSE_SECRET_KEY"); err != nil || !credentials.IsZero() {
+		t.Fatalf("expected no credentials when neither key is set, got %+v, %v", credentials, err)
+	}
+}
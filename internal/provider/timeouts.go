@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Default deadlines of the resource operations, used when the timeouts block leaves them unset. They bound
+// the whole operation, retries included, while request_timeout bounds each request attempt.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// importedTimeouts returns the unset timeouts block of a resource being imported, typed after its schema.
+func importedTimeouts(ctx context.Context, state tfsdk.State) (timeouts.Value, diag.Diagnostics) {
+	var value timeouts.Value
+	diags := state.GetAttribute(ctx, path.Root("timeouts"), &value)
+	return value, diags
+}
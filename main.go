@@ -3,18 +3,14 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/langfuse/terraform-provider-langfuse/internal/provider"
 )
 
-var (
-	version = "dev"
-	commit  = "none"
-	date    = "unknown"
-)
+// version is set at build time by goreleaser.
+var version = "dev"
 
 func main() {
 	var debug bool
@@ -27,9 +23,7 @@ func main() {
 		Debug:   debug,
 	}
 
-	providerVersion := fmt.Sprintf("terraform-provider-langfuse: version %s (Commit: %s Date: %s)\n", version, commit, date)
-
-	err := providerserver.Serve(context.Background(), provider.New(providerVersion), opts)
+	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
 	if err != nil {
 		log.Fatal(err.Error())